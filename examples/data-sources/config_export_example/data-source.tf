@@ -0,0 +1,30 @@
+terraform {
+  required_providers {
+    scylla = {
+      source  = "kiwicom/scylla"
+      version = "~> 1.0"
+    }
+    local = {
+      source = "hashicorp/local"
+    }
+  }
+}
+
+provider "scylla" {
+  contact_points = ["localhost"]
+  username       = "cassandra"
+  password       = "cassandra"
+}
+
+data "scylla_config_export" "existing" {}
+
+# Review the generated files, move them to the configuration and run terraform plan to adopt the roles.
+resource "local_file" "roles" {
+  filename = "${path.module}/generated/roles.tf"
+  content  = data.scylla_config_export.existing.hcl
+}
+
+resource "local_file" "imports" {
+  filename = "${path.module}/generated/imports.tf"
+  content  = data.scylla_config_export.existing.import_blocks
+}
@@ -0,0 +1,28 @@
+terraform {
+  required_providers {
+    scylla = {
+      source  = "kiwicom/scylla"
+      version = "~> 1.0"
+    }
+  }
+}
+
+provider "scylla" {
+  contact_points = ["localhost"]
+  username       = "cassandra"
+  password       = "cassandra"
+}
+
+data "scylla_materialized_views" "users" {
+  keyspace = "app"
+  table    = "users"
+}
+
+resource "scylla_table_grant" "reporting" {
+  for_each = toset([for view in data.scylla_materialized_views.users.views : view.name])
+
+  keyspace   = "app"
+  table      = each.key
+  grantee    = "reporting"
+  permission = "SELECT"
+}
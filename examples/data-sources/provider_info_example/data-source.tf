@@ -0,0 +1,30 @@
+terraform {
+  required_providers {
+    scylla = {
+      source  = "kiwicom/scylla"
+      version = "~> 1.0"
+    }
+  }
+}
+
+provider "scylla" {
+  contact_points = ["localhost"]
+  username       = "cassandra"
+  password       = "cassandra"
+}
+
+data "scylla_provider_info" "current" {}
+
+output "server_release" {
+  value = data.scylla_provider_info.current.server_release
+}
+
+# Fail the plan if the cluster is older than the module supports.
+resource "terraform_data" "minimum_version" {
+  lifecycle {
+    precondition {
+      condition     = tonumber(split(".", data.scylla_provider_info.current.server_release)[0]) >= 5
+      error_message = "Scylla 5.0 or newer is required."
+    }
+  }
+}
@@ -0,0 +1,23 @@
+terraform {
+  required_providers {
+    scylla = {
+      source  = "kiwicom/scylla"
+      version = "~> 1.0"
+    }
+  }
+}
+
+provider "scylla" {
+  contact_points = ["localhost"]
+  username       = "cassandra"
+  password       = "cassandra"
+}
+
+data "scylla_query" "tables" {
+  statement = "SELECT table_name, gc_grace_seconds FROM system_schema.tables WHERE keyspace_name = ?"
+  values    = ["system_auth"]
+}
+
+output "tables" {
+  value = [for row in data.scylla_query.tables.rows : row.table_name]
+}
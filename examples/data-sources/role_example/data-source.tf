@@ -0,0 +1,24 @@
+terraform {
+  required_providers {
+    scylla = {
+      source  = "kiwicom/scylla"
+      version = "~> 1.0"
+    }
+  }
+}
+
+provider "scylla" {
+  contact_points = ["localhost"]
+  username       = "cassandra"
+  password       = "cassandra"
+}
+
+data "scylla_role" "admin" {
+  name = "cassandra"
+}
+
+resource "scylla_keyspace_grant" "example" {
+  keyspace   = "system_traces"
+  grantee    = data.scylla_role.admin.name
+  permission = "SELECT"
+}
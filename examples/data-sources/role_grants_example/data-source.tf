@@ -0,0 +1,25 @@
+terraform {
+  required_providers {
+    scylla = {
+      source  = "kiwicom/scylla"
+      version = "~> 1.0"
+    }
+  }
+}
+
+provider "scylla" {
+  contact_points = ["localhost"]
+  username       = "cassandra"
+  password       = "cassandra"
+}
+
+data "scylla_role_grants" "example" {
+  role = "role1"
+}
+
+output "table_grants" {
+  value = [
+    for grant in data.scylla_role_grants.example.grants :
+    "${grant.permission} on ${grant.keyspace}.${grant.table}" if grant.resource_kind == "table"
+  ]
+}
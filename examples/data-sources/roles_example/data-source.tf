@@ -0,0 +1,26 @@
+terraform {
+  required_providers {
+    scylla = {
+      source  = "kiwicom/scylla"
+      version = "~> 1.0"
+    }
+  }
+}
+
+provider "scylla" {
+  contact_points = ["localhost"]
+  username       = "cassandra"
+  password       = "cassandra"
+}
+
+data "scylla_roles" "analytics" {
+  name_prefix = "analytics_"
+}
+
+resource "scylla_keyspace_grant" "analytics" {
+  for_each = { for role in data.scylla_roles.analytics.roles : role.name => role if !role.superuser }
+
+  keyspace   = "analytics"
+  grantee    = each.key
+  permission = "SELECT"
+}
@@ -0,0 +1,22 @@
+terraform {
+  required_providers {
+    scylla = {
+      source  = "kiwicom/scylla"
+      version = "~> 1.0"
+    }
+  }
+}
+
+provider "scylla" {
+  contact_points = ["localhost"]
+  username       = "cassandra"
+  password       = "cassandra"
+}
+
+data "scylla_schema" "app" {
+  keyspace = "app"
+}
+
+output "app_schema_sha256" {
+  value = data.scylla_schema.app.sha256
+}
@@ -0,0 +1,26 @@
+terraform {
+  required_providers {
+    scylla = {
+      source  = "kiwicom/scylla"
+      version = "~> 1.0"
+    }
+  }
+}
+
+provider "scylla" {
+  contact_points = ["localhost"]
+  username       = "cassandra"
+  password       = "cassandra"
+}
+
+data "scylla_types" "app" {
+  keyspace = "app"
+}
+
+locals {
+  type_names = [for t in data.scylla_types.app.types : t.name]
+}
+
+output "has_address_type" {
+  value = contains(local.type_names, "address")
+}
@@ -0,0 +1,5 @@
+# Grants on all functions in a keyspace can be imported using grantee/keyspace/permission
+terraform import scylla_functions_grant.keyspace analytics/analytics/EXECUTE
+
+# Grants on all functions can be imported using grantee/permission
+terraform import scylla_functions_grant.all analytics/EXECUTE
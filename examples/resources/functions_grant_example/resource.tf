@@ -0,0 +1,31 @@
+terraform {
+  required_providers {
+    scylla = {
+      source  = "kiwicom/scylla"
+      version = "~> 1.0"
+    }
+  }
+}
+
+provider "scylla" {
+  contact_points = ["localhost"]
+  username       = "cassandra"
+  password       = "cassandra"
+}
+
+resource "scylla_role" "analytics" {
+  name      = "analytics"
+  login     = false
+  superuser = false
+}
+
+resource "scylla_functions_grant" "all" {
+  grantee    = scylla_role.analytics.name
+  permission = "EXECUTE"
+}
+
+resource "scylla_functions_grant" "keyspace" {
+  keyspace   = "analytics"
+  grantee    = scylla_role.analytics.name
+  permission = "EXECUTE"
+}
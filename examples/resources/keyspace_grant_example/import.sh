@@ -0,0 +1,2 @@
+# Keyspace grants can be imported using grantee/keyspace/permission
+terraform import scylla_keyspace_grant.example my_role/my_keyspace/SELECT
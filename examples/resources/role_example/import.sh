@@ -0,0 +1,2 @@
+# Roles can be imported using their name
+terraform import scylla_role.example my_role
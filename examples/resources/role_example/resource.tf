@@ -8,9 +8,9 @@ terraform {
 }
 
 provider "scylla" {
-  hosts    = "localhost"
-  username = "cassandra"
-  password = "cassandra"
+  contact_points = ["localhost"]
+  username       = "cassandra"
+  password       = "cassandra"
 }
 
 resource "scylla_role" "example" {
@@ -34,3 +34,10 @@ resource "scylla_role" "with_password_and_sl" {
   password      = "hello world"
   service_level = scylla_service_level.sl2.name
 }
+
+resource "scylla_role" "member" {
+  name      = "role-member"
+  login     = true
+  superuser = false
+  member_of = [scylla_role.example.name]
+}
@@ -0,0 +1,2 @@
+# Permissions of a role can be imported using the role name
+terraform import scylla_role_permissions.analyst analyst
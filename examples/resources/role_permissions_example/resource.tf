@@ -0,0 +1,36 @@
+terraform {
+  required_providers {
+    scylla = {
+      source  = "kiwicom/scylla"
+      version = "~> 1.0"
+    }
+  }
+}
+
+provider "scylla" {
+  contact_points = ["localhost"]
+  username       = "cassandra"
+  password       = "cassandra"
+}
+
+resource "scylla_role" "analyst" {
+  name      = "analyst"
+  login     = false
+  superuser = false
+}
+
+resource "scylla_role_permissions" "analyst" {
+  role = scylla_role.analyst.name
+
+  permissions = [
+    {
+      keyspace   = "system_traces"
+      permission = "SELECT"
+    },
+    {
+      keyspace   = "system_traces"
+      table      = "sessions"
+      permission = "MODIFY"
+    },
+  ]
+}
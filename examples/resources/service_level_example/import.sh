@@ -0,0 +1,2 @@
+# Service levels can be imported using their name
+terraform import scylla_service_level.example my_service_level
@@ -0,0 +1,2 @@
+# Table grants can be imported using grantee/keyspace/table/permission
+terraform import scylla_table_grant.example my_role/my_keyspace/my_table/SELECT
@@ -8,9 +8,9 @@ terraform {
 }
 
 provider "scylla" {
-  hosts    = "localhost"
-  username = "cassandra"
-  password = "cassandra"
+  contact_points = ["localhost"]
+  username       = "cassandra"
+  password       = "cassandra"
 }
 
 resource "scylla_role" "role1" {
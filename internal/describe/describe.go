@@ -0,0 +1,545 @@
+// Package describe parses the output of DESCRIBE statements and rows of system_schema into structured metadata,
+// so that objects created outside of Terraform can be imported with all their attributes.
+package describe
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/kiwicom/terraform-provider-scylla/internal/qb"
+	"github.com/kiwicom/terraform-provider-scylla/internal/schemadiff"
+)
+
+// ParseTable parses the CREATE TABLE statement printed by DESC TABLE.
+// Statements following it, such as indexes and materialized views of the table, are ignored.
+// Option values are rendered the way qb renders them, so that they can be compared with configured ones.
+func ParseTable(output string) (*qb.Table, error) {
+	p, err := newParser(output)
+	if err != nil {
+		return nil, err
+	}
+	t, err := p.createTable()
+	if err != nil {
+		return nil, fmt.Errorf("parse table: %w", err)
+	}
+	return t, nil
+}
+
+// ParseKeyspace parses the CREATE KEYSPACE statement printed by DESC KEYSPACE.
+// Statements following it, such as tables of the keyspace, are ignored.
+func ParseKeyspace(output string) (*schemadiff.Keyspace, error) {
+	p, err := newParser(output)
+	if err != nil {
+		return nil, err
+	}
+	k, err := p.createKeyspace()
+	if err != nil {
+		return nil, fmt.Errorf("parse keyspace: %w", err)
+	}
+	return k, nil
+}
+
+type parser struct {
+	tokens []token
+	pos    int
+}
+
+func newParser(s string) (*parser, error) {
+	tokens, err := lex(s)
+	if err != nil {
+		return nil, err
+	}
+	return &parser{tokens: tokens}, nil
+}
+
+func (p *parser) peek() token {
+	if p.pos >= len(p.tokens) {
+		return token{kind: tokenEOF, pos: -1}
+	}
+	return p.tokens[p.pos]
+}
+
+func (p *parser) next() token {
+	t := p.peek()
+	if t.kind != tokenEOF {
+		p.pos++
+	}
+	return t
+}
+
+// isKeyword reports whether the token is the unquoted keyword, keywords are case-insensitive.
+func isKeyword(t token, keyword string) bool {
+	return t.kind == tokenIdent && strings.EqualFold(t.text, keyword)
+}
+
+func isSymbol(t token, symbol string) bool {
+	return t.kind == tokenSymbol && t.text == symbol
+}
+
+func (p *parser) acceptKeyword(keywords ...string) bool {
+	start := p.pos
+	for _, keyword := range keywords {
+		if !isKeyword(p.peek(), keyword) {
+			p.pos = start
+			return false
+		}
+		p.next()
+	}
+	return true
+}
+
+func (p *parser) expectKeyword(keywords ...string) error {
+	if !p.acceptKeyword(keywords...) {
+		return p.unexpected(strings.Join(keywords, " "))
+	}
+	return nil
+}
+
+func (p *parser) acceptSymbol(symbol string) bool {
+	if isSymbol(p.peek(), symbol) {
+		p.next()
+		return true
+	}
+	return false
+}
+
+func (p *parser) expectSymbol(symbol string) error {
+	if !p.acceptSymbol(symbol) {
+		return p.unexpected(symbol)
+	}
+	return nil
+}
+
+func (p *parser) unexpected(expected string) error {
+	t := p.peek()
+	if t.kind == tokenEOF {
+		return fmt.Errorf("expected %s, got end of input", expected)
+	}
+	return fmt.Errorf("expected %s, got %s at %d", expected, t, t.pos)
+}
+
+// name parses an identifier and returns the name it refers to.
+func (p *parser) name() (string, error) {
+	t := p.peek()
+	switch t.kind {
+	case tokenIdent:
+		p.next()
+		return strings.ToLower(t.text), nil
+	case tokenQuotedIdent:
+		p.next()
+		return t.text, nil
+	default:
+		return "", p.unexpected("identifier")
+	}
+}
+
+// qualifiedName parses a name optionally prefixed by keyspace.
+func (p *parser) qualifiedName() (keyspace, name string, err error) {
+	name, err = p.name()
+	if err != nil {
+		return "", "", err
+	}
+	if !p.acceptSymbol(".") {
+		return "", name, nil
+	}
+	keyspace = name
+	name, err = p.name()
+	return keyspace, name, err
+}
+
+func (p *parser) createKeyspace() (*schemadiff.Keyspace, error) {
+	if err := p.expectKeyword("CREATE", "KEYSPACE"); err != nil {
+		return nil, err
+	}
+	p.acceptKeyword("IF", "NOT", "EXISTS")
+	name, err := p.name()
+	if err != nil {
+		return nil, err
+	}
+	k := &schemadiff.Keyspace{Name: name}
+	if err := p.expectKeyword("WITH"); err != nil {
+		return nil, err
+	}
+	if err := p.options(&k.Options); err != nil {
+		return nil, err
+	}
+	return k, p.end()
+}
+
+func (p *parser) createTable() (*qb.Table, error) {
+	if err := p.expectKeyword("CREATE"); err != nil {
+		return nil, err
+	}
+	if !p.acceptKeyword("TABLE") && !p.acceptKeyword("COLUMNFAMILY") {
+		return nil, p.unexpected("TABLE")
+	}
+	p.acceptKeyword("IF", "NOT", "EXISTS")
+
+	t := &qb.Table{}
+	var err error
+	t.Keyspace, t.Name, err = p.qualifiedName()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := p.expectSymbol("("); err != nil {
+		return nil, err
+	}
+	for {
+		if p.acceptKeyword("PRIMARY", "KEY") {
+			if len(t.PartitionKey) > 0 {
+				return nil, fmt.Errorf("primary key defined more than once")
+			}
+			if err := p.primaryKey(t); err != nil {
+				return nil, err
+			}
+		} else if err := p.column(t); err != nil {
+			return nil, err
+		}
+		if p.acceptSymbol(")") {
+			break
+		}
+		if err := p.expectSymbol(","); err != nil {
+			return nil, err
+		}
+	}
+	if len(t.PartitionKey) == 0 {
+		return nil, fmt.Errorf("table %s.%s has no primary key", t.Keyspace, t.Name)
+	}
+
+	if p.acceptKeyword("WITH") {
+		if err := p.tableOptions(t); err != nil {
+			return nil, err
+		}
+	}
+	return t, p.end()
+}
+
+// column parses a column definition, including an inline PRIMARY KEY.
+func (p *parser) column(t *qb.Table) error {
+	name, err := p.name()
+	if err != nil {
+		return err
+	}
+	typ, err := p.columnType()
+	if err != nil {
+		return err
+	}
+	c := qb.Column{Name: name, Type: typ}
+	if p.acceptKeyword("STATIC") {
+		c.Static = true
+	}
+	if p.acceptKeyword("PRIMARY", "KEY") {
+		if len(t.PartitionKey) > 0 {
+			return fmt.Errorf("primary key defined more than once")
+		}
+		t.PartitionKey = []string{name}
+	}
+	t.Columns = append(t.Columns, c)
+	return nil
+}
+
+// columnType parses a type, such as int or map<text, frozen<list<int>>>, and renders it canonically.
+func (p *parser) columnType() (string, error) {
+	var name string
+	switch t := p.peek(); t.kind {
+	case tokenIdent:
+		p.next()
+		name = strings.ToLower(t.text)
+	case tokenQuotedIdent:
+		p.next()
+		name = string(qb.QName(t.text))
+	default:
+		return "", p.unexpected("type")
+	}
+	if p.acceptSymbol(".") {
+		// User defined type qualified by keyspace.
+		udt, err := p.name()
+		if err != nil {
+			return "", err
+		}
+		name += "." + udt
+	}
+	if !p.acceptSymbol("<") {
+		return name, nil
+	}
+
+	var params []string
+	for {
+		param, err := p.columnType()
+		if err != nil {
+			return "", err
+		}
+		params = append(params, param)
+		if p.acceptSymbol(">") {
+			break
+		}
+		if err := p.expectSymbol(","); err != nil {
+			return "", err
+		}
+	}
+	return name + "<" + strings.Join(params, ", ") + ">", nil
+}
+
+// primaryKey parses the key columns following PRIMARY KEY.
+func (p *parser) primaryKey(t *qb.Table) error {
+	if err := p.expectSymbol("("); err != nil {
+		return err
+	}
+	if p.acceptSymbol("(") {
+		for {
+			name, err := p.name()
+			if err != nil {
+				return err
+			}
+			t.PartitionKey = append(t.PartitionKey, name)
+			if p.acceptSymbol(")") {
+				break
+			}
+			if err := p.expectSymbol(","); err != nil {
+				return err
+			}
+		}
+	} else {
+		name, err := p.name()
+		if err != nil {
+			return err
+		}
+		t.PartitionKey = []string{name}
+	}
+	for !p.acceptSymbol(")") {
+		if err := p.expectSymbol(","); err != nil {
+			return err
+		}
+		name, err := p.name()
+		if err != nil {
+			return err
+		}
+		t.ClusteringKey = append(t.ClusteringKey, qb.ClusteringColumn{Name: name})
+	}
+	return nil
+}
+
+// tableOptions parses options following WITH, including CLUSTERING ORDER BY.
+func (p *parser) tableOptions(t *qb.Table) error {
+	for {
+		if p.acceptKeyword("CLUSTERING", "ORDER", "BY") {
+			if err := p.clusteringOrder(t); err != nil {
+				return err
+			}
+		} else if p.acceptKeyword("COMPACT", "STORAGE") {
+			return fmt.Errorf("tables with COMPACT STORAGE are not supported")
+		} else if err := p.option(&t.Options); err != nil {
+			return err
+		}
+		if !p.acceptKeyword("AND") {
+			return nil
+		}
+	}
+}
+
+func (p *parser) clusteringOrder(t *qb.Table) error {
+	if err := p.expectSymbol("("); err != nil {
+		return err
+	}
+	for {
+		name, err := p.name()
+		if err != nil {
+			return err
+		}
+		descending := false
+		if p.acceptKeyword("DESC") {
+			descending = true
+		} else {
+			p.acceptKeyword("ASC")
+		}
+		found := false
+		for i := range t.ClusteringKey {
+			if t.ClusteringKey[i].Name == name {
+				t.ClusteringKey[i].Descending = descending
+				found = true
+			}
+		}
+		if !found {
+			return fmt.Errorf("clustering order of %q, which is not a clustering column", name)
+		}
+		if p.acceptSymbol(")") {
+			return nil
+		}
+		if err := p.expectSymbol(","); err != nil {
+			return err
+		}
+	}
+}
+
+// options parses name = value pairs separated by AND.
+func (p *parser) options(o *qb.OptionsBuilder) error {
+	for {
+		if err := p.option(o); err != nil {
+			return err
+		}
+		if !p.acceptKeyword("AND") {
+			return nil
+		}
+	}
+}
+
+func (p *parser) option(o *qb.OptionsBuilder) error {
+	t := p.peek()
+	if t.kind != tokenIdent {
+		return p.unexpected("option name")
+	}
+	p.next()
+	if err := p.expectSymbol("="); err != nil {
+		return err
+	}
+	name := strings.ToLower(t.text)
+	var value qb.CQL
+	var err error
+	if name == "tablets" {
+		value, err = p.tablets()
+	} else {
+		value, err = p.value()
+	}
+	if err != nil {
+		return err
+	}
+	o.Set(name, value)
+	return nil
+}
+
+// tablets parses the tablets option of a keyspace and renders it the way qb.Tablets renders it.
+// The server omits enabled when tablets are enabled and prints zero initial when it chooses the number of tablets.
+func (p *parser) tablets() (qb.CQL, error) {
+	if err := p.expectSymbol("{"); err != nil {
+		return "", err
+	}
+	enabled, initial := true, 0
+	for first := true; !p.acceptSymbol("}"); first = false {
+		if !first {
+			if err := p.expectSymbol(","); err != nil {
+				return "", err
+			}
+		}
+		key := p.next()
+		if err := p.expectSymbol(":"); err != nil {
+			return "", err
+		}
+		value := p.next()
+		switch {
+		case key.kind == tokenString && key.text == "enabled" && value.kind == tokenIdent:
+			enabled = strings.EqualFold(value.text, "true")
+		case key.kind == tokenString && key.text == "initial" && value.kind == tokenNumber:
+			n, err := strconv.Atoi(value.text)
+			if err != nil {
+				return "", fmt.Errorf("invalid initial number of tablets %s: %w", value, err)
+			}
+			initial = n
+		default:
+			return "", fmt.Errorf("unsupported tablets option %s: %s", key, value)
+		}
+	}
+	return qb.Tablets(enabled, initial), nil
+}
+
+// value parses a literal and renders it the way qb renders it.
+func (p *parser) value() (qb.CQL, error) {
+	t := p.next()
+	switch t.kind {
+	case tokenString:
+		return qb.String(t.text), nil
+	case tokenNumber:
+		return qb.CQL(t.text), nil
+	case tokenIdent:
+		return qb.CQL(strings.ToLower(t.text)), nil
+	case tokenSymbol:
+		switch t.text {
+		case "{":
+			return p.mapOrSet()
+		case "[":
+			items, err := p.values("]")
+			return qb.List(items...), err
+		case "(":
+			items, err := p.values(")")
+			return qb.Tuple(items...), err
+		}
+	}
+	p.pos--
+	return "", p.unexpected("value")
+}
+
+// values parses values separated by commas up to the closing symbol.
+func (p *parser) values(closing string) ([]qb.CQL, error) {
+	var items []qb.CQL
+	if p.acceptSymbol(closing) {
+		return items, nil
+	}
+	for {
+		item, err := p.value()
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, item)
+		if p.acceptSymbol(closing) {
+			return items, nil
+		}
+		if err := p.expectSymbol(","); err != nil {
+			return nil, err
+		}
+	}
+}
+
+func (p *parser) mapOrSet() (qb.CQL, error) {
+	if p.acceptSymbol("}") {
+		return qb.Map(nil), nil
+	}
+	first, err := p.value()
+	if err != nil {
+		return "", err
+	}
+	if !p.acceptSymbol(":") {
+		items := []qb.CQL{first}
+		if !p.acceptSymbol("}") {
+			if err := p.expectSymbol(","); err != nil {
+				return "", err
+			}
+			rest, err := p.values("}")
+			if err != nil {
+				return "", err
+			}
+			items = append(items, rest...)
+		}
+		return qb.Set(items...), nil
+	}
+
+	m := make(map[qb.CQL]qb.CQL)
+	key := first
+	for {
+		value, err := p.value()
+		if err != nil {
+			return "", err
+		}
+		m[key] = value
+		if p.acceptSymbol("}") {
+			return qb.Map(m), nil
+		}
+		if err := p.expectSymbol(","); err != nil {
+			return "", err
+		}
+		if key, err = p.value(); err != nil {
+			return "", err
+		}
+		if err := p.expectSymbol(":"); err != nil {
+			return "", err
+		}
+	}
+}
+
+// end checks that the statement ends, following statements are ignored.
+func (p *parser) end() error {
+	if p.peek().kind == tokenEOF || p.acceptSymbol(";") {
+		return nil
+	}
+	return p.unexpected("end of statement")
+}
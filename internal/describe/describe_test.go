@@ -0,0 +1,147 @@
+package describe
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kiwicom/terraform-provider-scylla/internal/qb"
+	"github.com/kiwicom/terraform-provider-scylla/internal/schemadiff"
+)
+
+const descTable = `CREATE TABLE ks."Events" (
+    tenant text,
+    day date,
+    ts timestamp,
+    "Seq" int,
+    owner text static,
+    tags map<text, frozen<list<int>>>,
+    PRIMARY KEY ((tenant, day), ts, "Seq")
+) WITH CLUSTERING ORDER BY (ts DESC, "Seq" ASC)
+    AND bloom_filter_fp_chance = 0.01
+    AND caching = {'keys': 'ALL', 'rows_per_partition': 'ALL'}
+    AND comment = 'it''s a table'
+    AND compaction = {'class': 'SizeTieredCompactionStrategy'}
+    AND default_time_to_live = 0
+    AND speculative_retry = '99.0PERCENTILE';
+
+CREATE INDEX events_owner_idx ON ks."Events"(owner);
+`
+
+func TestParseTable(t *testing.T) {
+	table, err := ParseTable(descTable)
+	require.NoError(t, err)
+
+	assert.Equal(t, "ks", table.Keyspace)
+	assert.Equal(t, "Events", table.Name)
+	assert.Equal(t, []qb.Column{
+		{Name: "tenant", Type: "text"},
+		{Name: "day", Type: "date"},
+		{Name: "ts", Type: "timestamp"},
+		{Name: "Seq", Type: "int"},
+		{Name: "owner", Type: "text", Static: true},
+		{Name: "tags", Type: "map<text, frozen<list<int>>>"},
+	}, table.Columns)
+	assert.Equal(t, []string{"tenant", "day"}, table.PartitionKey)
+	assert.Equal(t, []qb.ClusteringColumn{{Name: "ts", Descending: true}, {Name: "Seq"}}, table.ClusteringKey)
+
+	assert.Equal(t, []string{"bloom_filter_fp_chance", "caching", "comment", "compaction",
+		"default_time_to_live", "speculative_retry"}, table.Options.Names())
+	caching, _ := table.Options.Get("caching")
+	assert.Equal(t, qb.StringMap(map[string]string{"keys": "ALL", "rows_per_partition": "ALL"}), caching)
+	comment, _ := table.Options.Get("comment")
+	assert.Equal(t, qb.String("it's a table"), comment)
+
+	// The parsed table renders to an equivalent statement.
+	stmt, err := table.CreateTable()
+	require.NoError(t, err)
+	reparsed, err := ParseTable(string(stmt))
+	require.NoError(t, err)
+	assert.Equal(t, table, reparsed)
+}
+
+func TestParseTable_InlinePrimaryKey(t *testing.T) {
+	table, err := ParseTable(`CREATE TABLE ks.users (id uuid PRIMARY KEY, name text) WITH comment = ''`)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"id"}, table.PartitionKey)
+	assert.Empty(t, table.ClusteringKey)
+	assert.Len(t, table.Columns, 2)
+}
+
+func TestParseTable_Invalid(t *testing.T) {
+	for _, s := range []string{
+		``,
+		`CREATE KEYSPACE ks WITH replication = {}`,
+		`CREATE TABLE ks.t (k int)`,
+		`CREATE TABLE ks.t (k int PRIMARY KEY, PRIMARY KEY (k))`,
+		`CREATE TABLE ks.t (k int, c int, PRIMARY KEY (k, c)) WITH CLUSTERING ORDER BY (x DESC)`,
+		`CREATE TABLE ks.t (k int PRIMARY KEY) WITH COMPACT STORAGE`,
+		`CREATE TABLE ks.t (k int PRIMARY KEY) WITH comment = 'unterminated`,
+		`CREATE TABLE ks.t (k int PRIMARY KEY) garbage`,
+	} {
+		_, err := ParseTable(s)
+		assert.Error(t, err, s)
+	}
+}
+
+func TestParseKeyspace(t *testing.T) {
+	keyspace, err := ParseKeyspace(`CREATE KEYSPACE "MyKs" WITH replication = {'class': 'NetworkTopologyStrategy', 'dc1': '3'}` +
+		` AND durable_writes = true;
+CREATE TABLE "MyKs".t (k int PRIMARY KEY);`)
+	require.NoError(t, err)
+	assert.Equal(t, "MyKs", keyspace.Name)
+	replication, _ := keyspace.Options.Get("replication")
+	assert.Equal(t, qb.StringMap(map[string]string{"class": "NetworkTopologyStrategy", "dc1": "3"}), replication)
+	durableWrites, _ := keyspace.Options.Get("durable_writes")
+	assert.Equal(t, qb.Bool(true), durableWrites)
+}
+
+func TestParseKeyspace_Tablets(t *testing.T) {
+	for output, want := range map[string]qb.CQL{
+		`{'initial': 0}`:                   qb.Tablets(true, 0),
+		`{'initial': 16}`:                  qb.Tablets(true, 16),
+		`{'enabled': true, 'initial': 16}`: qb.Tablets(true, 16),
+		`{'enabled': false}`:               qb.Tablets(false, 0),
+	} {
+		keyspace, err := ParseKeyspace(`CREATE KEYSPACE ks WITH replication = {'class': 'NetworkTopologyStrategy', 'dc1': '3'}` +
+			` AND durable_writes = true AND tablets = ` + output)
+		require.NoError(t, err, output)
+		tablets, _ := keyspace.Options.Get("tablets")
+		assert.Equal(t, want, tablets, output)
+	}
+
+	_, err := ParseKeyspace(`CREATE KEYSPACE ks WITH tablets = {'unknown': 1}`)
+	assert.Error(t, err)
+}
+
+func TestParseTable_EncryptionOptions(t *testing.T) {
+	table, err := ParseTable(`CREATE TABLE ks.t (k int PRIMARY KEY) WITH comment = ''
+    AND scylla_encryption_options = {'secret_key_strength': '128', 'key_provider': 'ReplicatedKeyProviderFactory', ` +
+		`'cipher_algorithm': 'AES/ECB/PKCS5Padding', 'system_key_file': 'system_key'};`)
+	require.NoError(t, err)
+
+	encryption := qb.EncryptionOptions{
+		KeyProvider:       "ReplicatedKeyProviderFactory",
+		CipherAlgorithm:   "AES/ECB/PKCS5Padding",
+		SecretKeyStrength: 128,
+		Options:           map[string]string{"system_key_file": "system_key"},
+	}
+	got, ok := table.Options.Get(qb.EncryptionOptionsName)
+	require.True(t, ok)
+	assert.Equal(t, encryption.CQL(), got)
+
+	desired := *table
+	desired.Options = qb.OptionsBuilder{}
+	desired.Options.Set(qb.EncryptionOptionsName, encryption.CQL())
+	stmts, err := schemadiff.AlterTable(table, &desired)
+	require.NoError(t, err)
+	assert.Empty(t, stmts)
+
+	encryption.Options["system_key_file"] = "rotated_key"
+	desired.Options.Set(qb.EncryptionOptionsName, encryption.CQL())
+	stmts, err = schemadiff.AlterTable(table, &desired)
+	require.NoError(t, err)
+	assert.Equal(t, []qb.CQL{`ALTER TABLE "ks"."t" WITH scylla_encryption_options = {'cipher_algorithm': 'AES/ECB/PKCS5Padding', ` +
+		`'key_provider': 'ReplicatedKeyProviderFactory', 'secret_key_strength': '128', 'system_key_file': 'rotated_key'}`}, stmts)
+}
@@ -0,0 +1,81 @@
+package describe
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/kiwicom/terraform-provider-scylla/internal/qb"
+)
+
+// SchemaColumn is a row of system_schema.columns.
+type SchemaColumn struct {
+	Name string `cql:"column_name"`
+
+	// Kind is partition_key, clustering, static or regular.
+	Kind string `cql:"kind"`
+
+	// Position is the position of the column within the partition or clustering key, -1 for other columns.
+	Position int32 `cql:"position"`
+
+	// ClusteringOrder is asc or desc for clustering columns, none for other columns.
+	ClusteringOrder string `cql:"clustering_order"`
+
+	Type string `cql:"type"`
+}
+
+// TableFromSchema builds the table from its rows of system_schema.columns, as an alternative to ParseTable
+// for clusters that do not support DESCRIBE as a statement. Options of the table are not set.
+// Columns are ordered as DESCRIBE orders them: partition key, clustering key, then the others by name.
+func TableFromSchema(keyspace, name string, columns []SchemaColumn) (*qb.Table, error) {
+	t := &qb.Table{Keyspace: keyspace, Name: name}
+
+	var partitionKey, clusteringKey, others []SchemaColumn
+	for _, c := range columns {
+		switch c.Kind {
+		case "partition_key":
+			partitionKey = append(partitionKey, c)
+		case "clustering":
+			clusteringKey = append(clusteringKey, c)
+		case "static", "regular":
+			others = append(others, c)
+		default:
+			return nil, fmt.Errorf("table %s.%s: column %q has unknown kind %q", keyspace, name, c.Name, c.Kind)
+		}
+	}
+	sort.Slice(partitionKey, func(i, j int) bool { return partitionKey[i].Position < partitionKey[j].Position })
+	sort.Slice(clusteringKey, func(i, j int) bool { return clusteringKey[i].Position < clusteringKey[j].Position })
+	sort.Slice(others, func(i, j int) bool { return others[i].Name < others[j].Name })
+
+	for _, group := range [][]SchemaColumn{partitionKey, clusteringKey, others} {
+		for _, c := range group {
+			typ, err := canonicalType(c.Type)
+			if err != nil {
+				return nil, fmt.Errorf("table %s.%s: column %q: %w", keyspace, name, c.Name, err)
+			}
+			t.Columns = append(t.Columns, qb.Column{Name: c.Name, Type: typ, Static: c.Kind == "static"})
+		}
+	}
+	for _, c := range partitionKey {
+		t.PartitionKey = append(t.PartitionKey, c.Name)
+	}
+	for _, c := range clusteringKey {
+		t.ClusteringKey = append(t.ClusteringKey, qb.ClusteringColumn{Name: c.Name, Descending: c.ClusteringOrder == "desc"})
+	}
+	return t, nil
+}
+
+// canonicalType renders the type the way ParseTable renders types of columns.
+func canonicalType(s string) (string, error) {
+	p, err := newParser(s)
+	if err != nil {
+		return "", err
+	}
+	typ, err := p.columnType()
+	if err != nil {
+		return "", err
+	}
+	if p.peek().kind != tokenEOF {
+		return "", p.unexpected("end of type")
+	}
+	return typ, nil
+}
@@ -0,0 +1,42 @@
+package describe
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kiwicom/terraform-provider-scylla/internal/qb"
+)
+
+func TestTableFromSchema(t *testing.T) {
+	// Rows of system_schema.columns of the table of descTable, in the order they are returned.
+	columns := []SchemaColumn{
+		{Name: "Seq", Kind: "clustering", Position: 1, ClusteringOrder: "asc", Type: "int"},
+		{Name: "day", Kind: "partition_key", Position: 1, ClusteringOrder: "none", Type: "date"},
+		{Name: "owner", Kind: "static", Position: -1, ClusteringOrder: "none", Type: "text"},
+		{Name: "tags", Kind: "regular", Position: -1, ClusteringOrder: "none", Type: "map<text,frozen<list<int>>>"},
+		{Name: "tenant", Kind: "partition_key", Position: 0, ClusteringOrder: "none", Type: "text"},
+		{Name: "ts", Kind: "clustering", Position: 0, ClusteringOrder: "desc", Type: "timestamp"},
+	}
+	table, err := TableFromSchema("ks", "Events", columns)
+	require.NoError(t, err)
+
+	described, err := ParseTable(descTable)
+	require.NoError(t, err)
+	described.Options = qb.OptionsBuilder{}
+	assert.Equal(t, described, table)
+
+	stmt, err := table.CreateTable()
+	require.NoError(t, err)
+	assert.Equal(t, qb.CQL(`CREATE TABLE "ks"."Events" ("tenant" text, "day" date, "ts" timestamp, "Seq" int, `+
+		`"owner" text STATIC, "tags" map<text, frozen<list<int>>>, PRIMARY KEY (("tenant", "day"), "ts", "Seq"))`+
+		` WITH CLUSTERING ORDER BY ("ts" DESC, "Seq" ASC)`), stmt)
+}
+
+func TestTableFromSchema_Invalid(t *testing.T) {
+	_, err := TableFromSchema("ks", "t", []SchemaColumn{{Name: "k", Kind: "partition_key", Type: "int"}, {Name: "v", Kind: "compact_value", Type: "blob"}})
+	assert.Error(t, err)
+	_, err = TableFromSchema("ks", "t", []SchemaColumn{{Name: "k", Kind: "partition_key", Type: "map<int"}})
+	assert.Error(t, err)
+}
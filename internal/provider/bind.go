@@ -0,0 +1,48 @@
+package provider
+
+import (
+	"encoding/binary"
+	"fmt"
+	"time"
+
+	"github.com/scylladb/scylla-go-driver/frame"
+)
+
+// bind converts Go values to CQL values of the matching type, so that they can be passed to execute.
+// Supported are string (text), int64 (bigint), int32 (int), bool (boolean), frame.UUID (uuid)
+// and time.Time (timestamp, with millisecond precision).
+func bind(values ...interface{}) ([]frame.CqlValue, error) {
+	cqlValues := make([]frame.CqlValue, len(values))
+	for i, v := range values {
+		cqlValue, err := bindValue(v)
+		if err != nil {
+			return nil, fmt.Errorf("bind value %d: %w", i, err)
+		}
+		cqlValues[i] = cqlValue
+	}
+	return cqlValues, nil
+}
+
+func bindValue(v interface{}) (frame.CqlValue, error) {
+	switch v := v.(type) {
+	case string:
+		return frame.CqlFromText(v)
+	case int64:
+		return frame.CqlFromInt64(v), nil
+	case int32:
+		return frame.CqlFromInt32(v), nil
+	case bool:
+		return frame.CqlFromBoolean(v), nil
+	case frame.UUID:
+		return frame.CqlFromUUID(v), nil
+	case time.Time:
+		value := make([]byte, 8)
+		binary.BigEndian.PutUint64(value, uint64(v.UnixMilli()))
+		return frame.CqlValue{
+			Type:  &frame.Option{ID: frame.TimestampID},
+			Value: value,
+		}, nil
+	default:
+		return frame.CqlValue{}, fmt.Errorf("unsupported type %T", v)
+	}
+}
@@ -0,0 +1,45 @@
+package provider
+
+import (
+	"testing"
+	"time"
+
+	"github.com/scylladb/scylla-go-driver/frame"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBind(t *testing.T) {
+	uuid := frame.UUID{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16}
+	ts := time.Date(2022, 10, 1, 12, 0, 0, 0, time.UTC)
+
+	values, err := bind("ks", int64(-5), int32(7), true, uuid, ts)
+	require.NoError(t, err)
+	require.Len(t, values, 6)
+
+	text, err := values[0].AsText()
+	require.NoError(t, err)
+	assert.Equal(t, "ks", text)
+
+	bigint, err := values[1].AsInt64()
+	require.NoError(t, err)
+	assert.Equal(t, int64(-5), bigint)
+
+	i, err := values[2].AsInt32()
+	require.NoError(t, err)
+	assert.Equal(t, int32(7), i)
+
+	b, err := values[3].AsBoolean()
+	require.NoError(t, err)
+	assert.True(t, b)
+
+	u, err := values[4].AsUUID()
+	require.NoError(t, err)
+	assert.Equal(t, [16]byte(uuid), u)
+
+	assert.Equal(t, frame.TimestampID, values[5].Type.ID)
+	assert.Equal(t, []byte{0, 0, 0x01, 0x83, 0x93, 0x6a, 0xe6, 0x00}, values[5].Value)
+
+	_, err = bind(3.14)
+	assert.Error(t, err)
+}
@@ -0,0 +1,318 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/kiwicom/terraform-provider-scylla/internal/scan"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces
+var _ tfsdk.DataSourceType = configExportDataSourceType{}
+var _ tfsdk.DataSource = configExportDataSource{}
+
+type configExportDataSourceType struct{}
+
+func (t configExportDataSourceType) GetSchema(ctx context.Context) (tfsdk.Schema, diag.Diagnostics) {
+	return tfsdk.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Renders the roles of the cluster, their attached service levels and their grants " +
+			"as Terraform configuration, together with the import blocks that adopt them into the state. " +
+			"It is meant to bootstrap managing an existing cluster with Terraform: " +
+			"write `hcl` and `import_blocks` to files, review them and apply. " +
+			"Passwords are not exported, as the server only stores their salted hashes.",
+
+		Attributes: map[string]tfsdk.Attribute{
+			"roles": {
+				MarkdownDescription: "Names of the roles to export. All roles are exported if not set.",
+				Optional:            true,
+				Type:                types.ListType{ElemType: types.StringType},
+			},
+			"id": {
+				MarkdownDescription: "Always `export`",
+				Computed:            true,
+				Type:                types.StringType,
+			},
+			"hcl": {
+				MarkdownDescription: "Resource blocks of the exported roles, service levels and grants. " +
+					"Grants that no resource of the provider manages individually, such as grants on all keyspaces, " +
+					"are listed as comments.",
+				Computed: true,
+				Type:     types.StringType,
+			},
+			"import_blocks": {
+				MarkdownDescription: "Import blocks of the exported resources, supported by Terraform 1.5 and newer.",
+				Computed:            true,
+				Type:                types.StringType,
+			},
+		},
+	}, nil
+}
+
+func (t configExportDataSourceType) NewDataSource(ctx context.Context, in tfsdk.Provider) (tfsdk.DataSource, diag.Diagnostics) {
+	provider, diags := convertProviderType(in)
+
+	return configExportDataSource{
+		provider: provider,
+	}, diags
+}
+
+type configExportDataSourceData struct {
+	Roles        types.List   `tfsdk:"roles"`
+	Id           types.String `tfsdk:"id"`
+	HCL          types.String `tfsdk:"hcl"`
+	ImportBlocks types.String `tfsdk:"import_blocks"`
+}
+
+type configExportDataSource struct {
+	provider provider
+}
+
+// configExport collects the rendered resources and their import blocks.
+type configExport struct {
+	hcl          strings.Builder
+	importBlocks strings.Builder
+
+	// names are the resource names used so far, keyed by resource type.
+	names map[string]map[string]struct{}
+}
+
+// add renders the resource block and its import block. The name of the resource is derived from name,
+// with a numeric suffix if it collides with another resource of the same type.
+func (e *configExport) add(resourceType, name, importID string, block *hclBlock) {
+	base := hclIdentifier(name)
+	if e.names == nil {
+		e.names = make(map[string]map[string]struct{})
+	}
+	if e.names[resourceType] == nil {
+		e.names[resourceType] = make(map[string]struct{})
+	}
+	unique := base
+	for i := 2; ; i++ {
+		if _, ok := e.names[resourceType][unique]; !ok {
+			break
+		}
+		unique = fmt.Sprintf("%s_%d", base, i)
+	}
+	e.names[resourceType][unique] = struct{}{}
+
+	block.header = fmt.Sprintf("resource %s %s", hclString(resourceType), hclString(unique))
+	if e.hcl.Len() > 0 {
+		e.hcl.WriteString("\n")
+	}
+	block.writeTo(&e.hcl)
+
+	imp := newHCLBlock("import")
+	imp.set("to", resourceType+"."+unique)
+	imp.set("id", hclString(importID))
+	if e.importBlocks.Len() > 0 {
+		e.importBlocks.WriteString("\n")
+	}
+	imp.writeTo(&e.importBlocks)
+}
+
+// comment adds a comment line to the resource blocks.
+func (e *configExport) comment(text string) {
+	if e.hcl.Len() > 0 {
+		e.hcl.WriteString("\n")
+	}
+	e.hcl.WriteString("# " + text + "\n")
+}
+
+func (d configExportDataSource) Read(ctx context.Context, req tfsdk.ReadDataSourceRequest, resp *tfsdk.ReadDataSourceResponse) {
+	var data configExportDataSourceData
+
+	diags := req.Config.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var roles []string
+	if data.Roles.IsNull() {
+		var err error
+		roles, err = d.listRoleNames(ctx)
+		if err != nil {
+			resp.Diagnostics.Append(cqlErrorDiagnostic("Query error", "Unable to list roles.", err))
+			return
+		}
+	} else {
+		resp.Diagnostics.Append(data.Roles.ElementsAs(ctx, &roles, false)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		sort.Strings(roles)
+	}
+
+	var export configExport
+	serviceLevels := make(map[string]struct{})
+	for _, role := range roles {
+		roleData := roleResourceData{
+			Name:       types.String{Value: role},
+			Id:         types.String{Value: role},
+			Password:   types.String{Null: true},
+			SaltedHash: types.String{Null: true},
+			MemberOf:   types.Set{ElemType: types.StringType, Elems: []attr.Value{}},
+		}
+		found, diags := roleResource{provider: d.provider}.readData(ctx, &roleData)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		if !found {
+			resp.Diagnostics.AddError("Role not found", fmt.Sprintf("Role %q does not exist.", role))
+			return
+		}
+
+		if !roleData.ServiceLevel.IsNull() {
+			if _, ok := serviceLevels[roleData.ServiceLevel.Value]; !ok {
+				serviceLevels[roleData.ServiceLevel.Value] = struct{}{}
+				resp.Diagnostics.Append(d.exportServiceLevel(ctx, &export, roleData.ServiceLevel.Value)...)
+				if resp.Diagnostics.HasError() {
+					return
+				}
+			}
+		}
+
+		block := &hclBlock{}
+		block.set("name", hclString(role))
+		block.set("login", fmt.Sprint(roleData.Login.Value))
+		block.set("superuser", fmt.Sprint(roleData.Superuser.Value))
+		if len(roleData.MemberOf.Elems) > 0 {
+			memberOf := make([]string, 0, len(roleData.MemberOf.Elems))
+			for _, elem := range roleData.MemberOf.Elems {
+				memberOf = append(memberOf, elem.(types.String).Value)
+			}
+			sort.Strings(memberOf)
+			block.set("member_of", hclStringList(memberOf))
+		}
+		if !roleData.ServiceLevel.IsNull() {
+			block.set("service_level", hclString(roleData.ServiceLevel.Value))
+		}
+		export.add("scylla_role", role, role, block)
+
+		if err := d.exportGrants(ctx, &export, role); err != nil {
+			resp.Diagnostics.Append(cqlErrorDiagnostic("Query error",
+				fmt.Sprintf("Unable to list permissions of role %q.", role), err))
+			return
+		}
+	}
+
+	data.Id = types.String{Value: "export"}
+	data.HCL = types.String{Value: export.hcl.String()}
+	data.ImportBlocks = types.String{Value: export.importBlocks.String()}
+
+	diags = resp.State.Set(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+}
+
+// listRoleNames returns the names of all roles, sorted.
+func (d configExportDataSource) listRoleNames(ctx context.Context) ([]string, error) {
+	result, err := d.provider.executeAuthRead(ctx, "LIST ROLES", nil)
+	if err != nil {
+		return nil, err
+	}
+	var rows []struct {
+		Role string `cql:"role"`
+	}
+	if err := scan.Rows(result, &rows); err != nil {
+		return nil, err
+	}
+	names := make([]string, 0, len(rows))
+	for _, row := range rows {
+		names = append(names, row.Role)
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+// exportServiceLevel renders the service level.
+func (d configExportDataSource) exportServiceLevel(ctx context.Context, export *configExport, name string) diag.Diagnostics {
+	data := serviceLevelResourceData{
+		Name:    types.String{Value: name},
+		Id:      types.String{Value: name},
+		Timeout: types.String{Null: true},
+	}
+	found, diags := serviceLevelResource{provider: d.provider}.readData(ctx, &data)
+	if diags.HasError() {
+		return diags
+	}
+	if !found {
+		// Attached service levels are dropped together with their attachments, but the two reads are not atomic.
+		tflog.Warn(ctx, "Attached service level not found", map[string]interface{}{"service_level": name})
+		return diags
+	}
+
+	block := &hclBlock{}
+	block.set("name", hclString(name))
+	if !data.Shares.IsNull() {
+		block.set("shares", fmt.Sprint(data.Shares.Value))
+	}
+	if !data.WorkloadType.IsNull() {
+		block.set("workload_type", hclString(data.WorkloadType.Value))
+	}
+	if !data.Timeout.IsNull() {
+		block.set("timeout", hclString(data.Timeout.Value))
+	}
+	export.add("scylla_service_level", name, name, block)
+	return diags
+}
+
+// exportGrants renders the permissions granted directly to the role.
+func (d configExportDataSource) exportGrants(ctx context.Context, export *configExport, role string) error {
+	rows, err := d.provider.listPermissions(ctx, role)
+	if err != nil {
+		return err
+	}
+	rows = append([]permissionRow(nil), rows...)
+	sort.Slice(rows, func(i, j int) bool {
+		if rows[i].Resource != rows[j].Resource {
+			return rows[i].Resource < rows[j].Resource
+		}
+		return rows[i].Permission < rows[j].Permission
+	})
+
+	for _, row := range rows {
+		resource := parseListResource(row.Resource)
+		block := &hclBlock{}
+		block.set("grantee", hclString(role))
+		switch {
+		case resource.kind == "keyspace":
+			block.set("keyspace", hclString(resource.keyspace))
+			block.set("permission", hclString(row.Permission))
+			export.add("scylla_keyspace_grant",
+				strings.Join([]string{role, resource.keyspace, row.Permission}, "_"),
+				strings.Join([]string{role, resource.keyspace, row.Permission}, "/"), block)
+		case resource.kind == "table":
+			block.set("keyspace", hclString(resource.keyspace))
+			block.set("table", hclString(resource.table))
+			block.set("permission", hclString(row.Permission))
+			export.add("scylla_table_grant",
+				strings.Join([]string{role, resource.keyspace, resource.table, row.Permission}, "_"),
+				strings.Join([]string{role, resource.keyspace, resource.table, row.Permission}, "/"), block)
+		case resource.kind == "all_functions" && resource.keyspace != "":
+			block.set("keyspace", hclString(resource.keyspace))
+			block.set("permission", hclString(row.Permission))
+			export.add("scylla_functions_grant",
+				strings.Join([]string{role, resource.keyspace, "functions", row.Permission}, "_"),
+				strings.Join([]string{role, resource.keyspace, row.Permission}, "/"), block)
+		case resource.kind == "all_functions":
+			block.set("permission", hclString(row.Permission))
+			export.add("scylla_functions_grant",
+				strings.Join([]string{role, "functions", row.Permission}, "_"),
+				strings.Join([]string{role, row.Permission}, "/"), block)
+		default:
+			export.comment(fmt.Sprintf("%s ON %s granted to %q is not exported", row.Permission, row.Resource, role))
+		}
+	}
+	return nil
+}
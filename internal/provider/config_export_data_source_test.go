@@ -0,0 +1,131 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfigExportDataSourceRead(t *testing.T) {
+	cluster := newFakeCluster()
+	cluster.addRole("readers")
+	app := cluster.addRole("app")
+	app.login = true
+	app.memberOf["readers"] = struct{}{}
+	cluster.serviceLevels["oltp"] = &fakeServiceLevel{options: map[string]string{
+		"timeout": "500ms", "workload_type": "interactive",
+	}}
+	cluster.attached["app"] = "oltp"
+	cluster.permissions["app"] = map[string]map[string]struct{}{
+		"<table ks.events>":    {"SELECT": {}, "MODIFY": {}},
+		"<all keyspaces>":      {"DESCRIBE": {}},
+		"<all functions>":      {"EXECUTE": {}},
+		"<keyspace ${ks}>":     {"SELECT": {}},
+		"<keyspace reporting>": {"SELECT": {}},
+	}
+
+	ds := newTestDataSource(t, configExportDataSourceType{}, cluster.provider())
+	resp := ds.read(&configExportDataSourceData{
+		Roles:        types.List{ElemType: types.StringType, Null: true},
+		Id:           types.String{Null: true},
+		HCL:          types.String{Null: true},
+		ImportBlocks: types.String{Null: true},
+	})
+	require.False(t, resp.Diagnostics.HasError(), "%v", resp.Diagnostics)
+
+	var data configExportDataSourceData
+	getTestState(t, resp.State, &data)
+	assert.Equal(t, `resource "scylla_service_level" "oltp" {
+  name          = "oltp"
+  workload_type = "interactive"
+  timeout       = "500ms"
+}
+
+resource "scylla_role" "app" {
+  name          = "app"
+  login         = true
+  superuser     = false
+  member_of     = ["readers"]
+  service_level = "oltp"
+}
+
+resource "scylla_functions_grant" "app_functions_execute" {
+  grantee    = "app"
+  permission = "EXECUTE"
+}
+
+# DESCRIBE ON <all keyspaces> granted to "app" is not exported
+
+resource "scylla_keyspace_grant" "app___ks__select" {
+  grantee    = "app"
+  keyspace   = "$${ks}"
+  permission = "SELECT"
+}
+
+resource "scylla_keyspace_grant" "app_reporting_select" {
+  grantee    = "app"
+  keyspace   = "reporting"
+  permission = "SELECT"
+}
+
+resource "scylla_table_grant" "app_ks_events_modify" {
+  grantee    = "app"
+  keyspace   = "ks"
+  table      = "events"
+  permission = "MODIFY"
+}
+
+resource "scylla_table_grant" "app_ks_events_select" {
+  grantee    = "app"
+  keyspace   = "ks"
+  table      = "events"
+  permission = "SELECT"
+}
+
+resource "scylla_role" "readers" {
+  name      = "readers"
+  login     = false
+  superuser = false
+}
+`, data.HCL.Value)
+	assert.Contains(t, data.ImportBlocks.Value, `import {
+  to = scylla_role.app
+  id = "app"
+}
+`)
+	assert.Contains(t, data.ImportBlocks.Value, `import {
+  to = scylla_keyspace_grant.app___ks__select
+  id = "app/$${ks}/SELECT"
+}
+`)
+	assert.Contains(t, data.ImportBlocks.Value, `import {
+  to = scylla_functions_grant.app_functions_execute
+  id = "app/EXECUTE"
+}
+`)
+}
+
+func TestConfigExportDataSourceReadRoleNotFound(t *testing.T) {
+	cluster := newFakeCluster()
+	ds := newTestDataSource(t, configExportDataSourceType{}, cluster.provider())
+
+	resp := ds.read(&configExportDataSourceData{
+		Roles:        types.List{ElemType: types.StringType, Elems: []attr.Value{types.String{Value: "missing"}}},
+		Id:           types.String{Null: true},
+		HCL:          types.String{Null: true},
+		ImportBlocks: types.String{Null: true},
+	})
+	require.True(t, resp.Diagnostics.HasError())
+	assert.Equal(t, "Role not found", resp.Diagnostics[0].Summary())
+}
+
+func TestHCLIdentifier(t *testing.T) {
+	assert.Equal(t, "app", hclIdentifier("app"))
+	assert.Equal(t, "my_app", hclIdentifier("My App"))
+	assert.Equal(t, "_1st", hclIdentifier("1st"))
+	assert.Equal(t, "_app-1", hclIdentifier("-app-1"))
+	assert.Equal(t, "_", hclIdentifier(""))
+}
@@ -0,0 +1,43 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/scylladb/scylla-go-driver/frame"
+)
+
+// cqlLogSubsystem is the tflog subsystem of executed statements.
+// Its level can be set separately with the TF_LOG_PROVIDER_SCYLLA_CQL environment variable.
+const cqlLogSubsystem = "cql"
+
+// withCQLLog returns ctx with the cql log subsystem set up.
+func withCQLLog(ctx context.Context) context.Context {
+	return tflog.NewSubsystem(ctx, cqlLogSubsystem, tflog.WithLevelFromEnv("TF_LOG_PROVIDER_SCYLLA_CQL"))
+}
+
+// passwordLiteral matches passwords in CREATE ROLE and ALTER ROLE statements.
+var passwordLiteral = regexp.MustCompile(`(?i)(\bPASSWORD\s*=\s*)'(?:[^']|'')*'`)
+
+// redactStatement replaces secrets in the statement so that it can be logged.
+// Bound values are never logged, so only literals need to be redacted.
+func redactStatement(query string) string {
+	return passwordLiteral.ReplaceAllString(query, "${1}'***'")
+}
+
+// consistencyName returns the name of the consistency level as accepted by the consistency and serial_consistency attributes.
+func consistencyName(c frame.Consistency) string {
+	for name, v := range consistencies {
+		if v == c {
+			return name
+		}
+	}
+	for name, v := range serialConsistencies {
+		if v == c {
+			return name
+		}
+	}
+	return fmt.Sprintf("0x%04x", uint16(c))
+}
@@ -0,0 +1,22 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/scylladb/scylla-go-driver/frame"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRedactStatement(t *testing.T) {
+	assert.Equal(t, `CREATE ROLE "app" WITH LOGIN = true AND SUPERUSER = false AND PASSWORD = '***'`,
+		redactStatement(`CREATE ROLE "app" WITH LOGIN = true AND SUPERUSER = false AND PASSWORD = 'it''s secret'`))
+	assert.Equal(t, `ALTER ROLE "app" WITH password='***'`, redactStatement(`ALTER ROLE "app" WITH password='x'`))
+	assert.Equal(t, `SELECT role FROM system.roles WHERE role = ?`,
+		redactStatement(`SELECT role FROM system.roles WHERE role = ?`))
+}
+
+func TestConsistencyName(t *testing.T) {
+	assert.Equal(t, "LOCAL_QUORUM", consistencyName(frame.LOCALQUORUM))
+	assert.Equal(t, "LOCAL_SERIAL", consistencyName(frame.LOCALSERIAL))
+	assert.Equal(t, "0x00ff", consistencyName(frame.Consistency(0xff)))
+}
@@ -0,0 +1,90 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+)
+
+// edition is the distribution of Scylla the provider is connected to.
+type edition int
+
+const (
+	// editionUnknown is used when the edition could not be detected, checks depending on it are skipped.
+	editionUnknown edition = iota
+	editionOpenSource
+	editionEnterprise
+)
+
+// enterpriseMinMajor is the lowest major version of Scylla Enterprise, whose versions are numbered by year.
+const enterpriseMinMajor = 2018
+
+// editionCache holds the edition detected on first use, so that only runs using it query the version.
+// Failed detection is not cached, it is tried again and reported to every caller. The zero value is ready to use.
+type editionCache struct {
+	// mu is held while detecting, so that concurrent callers wait for a single detection.
+	mu       sync.Mutex
+	detected bool
+	edition  edition
+}
+
+// clusterEdition returns the edition of the cluster, detecting it on first use.
+// If detection fails, the edition is unknown and a warning is returned.
+func (p *provider) clusterEdition(ctx context.Context) (edition, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	if !p.configured || p.executor == nil {
+		// The provider is not configured when planning with unknown provider attributes.
+		return editionUnknown, diags
+	}
+
+	if p.editions != nil {
+		p.editions.mu.Lock()
+		defer p.editions.mu.Unlock()
+		if p.editions.detected {
+			return p.editions.edition, diags
+		}
+	}
+	e, err := p.detectEdition(ctx)
+	if err != nil {
+		diags.AddWarning("Unable to detect Scylla edition",
+			fmt.Sprintf("Attributes supported only by some editions of Scylla are not checked: %s", err))
+		return editionUnknown, diags
+	}
+	if p.editions != nil {
+		p.editions.detected = true
+		p.editions.edition = e
+	}
+	return e, diags
+}
+
+// detectEdition reads the version of the node the provider is connected to.
+func (p *provider) detectEdition(ctx context.Context) (edition, error) {
+	result, err := p.execute(ctx, "SELECT version FROM system.versions WHERE key = 'local'", nil)
+	if err != nil {
+		return editionUnknown, err
+	}
+	if len(result.Rows) == 0 {
+		return editionUnknown, fmt.Errorf("version of the node not found")
+	}
+	version, err := result.Rows[0][0].AsText()
+	if err != nil {
+		return editionUnknown, err
+	}
+	return parseEdition(version), nil
+}
+
+// parseEdition derives the edition from a version such as 5.1.0 or 2022.1.3-0.20220922.
+func parseEdition(version string) edition {
+	major, err := strconv.Atoi(strings.SplitN(version, ".", 2)[0])
+	if err != nil {
+		return editionUnknown
+	}
+	if major >= enterpriseMinMajor {
+		return editionEnterprise
+	}
+	return editionOpenSource
+}
@@ -0,0 +1,244 @@
+package provider
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/scylladb/scylla-go-driver/frame"
+	"github.com/scylladb/scylla-go-driver/frame/response"
+)
+
+// errorClass is a category of errors of executed statements that share the same remediation.
+type errorClass int
+
+const (
+	errorClassOther errorClass = iota
+	errorClassConnection
+	errorClassUnauthorized
+	errorClassSyntax
+	errorClassUnavailable
+	errorClassTimeout
+	errorClassAlreadyExists
+	errorClassNotFound
+	errorClassInvalid
+	errorClassCredentials
+	errorClassAuthNotEnabled
+	errorClassUnsupportedAuthenticator
+)
+
+// errorClassInfo holds the text shown to the user for an error class.
+type errorClassInfo struct {
+	title       string
+	remediation string
+}
+
+var errorClasses = map[errorClass]errorClassInfo{
+	errorClassConnection: {
+		title: "connection failed",
+		remediation: "Check that the hosts are reachable from where Terraform runs " +
+			"and that the port, TLS and proxy settings of the provider match the cluster.",
+	},
+	errorClassUnauthorized: {
+		title: "permission denied",
+		remediation: "The role the provider is connected as lacks a permission required for the statement. " +
+			"Grant it the permission, or connect as a role that has it, for example a superuser.",
+	},
+	errorClassSyntax: {
+		title: "syntax error",
+		remediation: "The statement was rejected by the parser. If it was generated by the provider, " +
+			"check that the cluster version supports it, otherwise please report it as a bug.",
+	},
+	errorClassUnavailable: {
+		title: "cluster unavailable",
+		remediation: "Not enough replicas are alive to satisfy the consistency level. " +
+			"Wait for the nodes to come back, or lower consistency or auth_consistency of the provider. " +
+			"Such errors can be retried automatically using the retry settings of the provider.",
+	},
+	errorClassTimeout: {
+		title: "timeout",
+		remediation: "The replicas did not respond in time, the cluster may be overloaded. " +
+			"The statement may or may not have been applied, run terraform plan to see the current state. " +
+			"Such errors can be retried automatically using the retry settings of the provider.",
+	},
+	errorClassAlreadyExists: {
+		title: "already exists",
+		remediation: "The object exists already, for example because it was created outside of Terraform. " +
+			"Import it with terraform import, or remove it from the cluster.",
+	},
+	errorClassNotFound: {
+		title: "not found",
+		remediation: "An object referenced by the statement does not exist. " +
+			"Check the names in the configuration, and that the object was not dropped outside of Terraform.",
+	},
+	errorClassInvalid: {
+		title:       "invalid request",
+		remediation: "The cluster rejected the statement, check the values in the configuration.",
+	},
+	errorClassCredentials: {
+		title: "bad credentials",
+		remediation: "The cluster rejected the username and password. Check username and password of the provider, " +
+			"or the SCYLLA_USERNAME and SCYLLA_PASSWORD environment variables, and that the role is allowed to log in.",
+	},
+	errorClassAuthNotEnabled: {
+		title: "authentication not enabled",
+		remediation: "The cluster does not authenticate clients, so the provider is connected anonymously " +
+			"and cannot manage roles and permissions. Set authenticator to PasswordAuthenticator in scylla.yaml.",
+	},
+	errorClassUnsupportedAuthenticator: {
+		title: "unsupported authenticator",
+		remediation: "The cluster asks for an authenticator the driver does not support. " +
+			"Only PasswordAuthenticator, TransitionalAuthenticator and AllowAllAuthenticator are supported.",
+	},
+}
+
+// notFoundMessages are fragments of messages of invalid request errors returned for missing objects.
+// The protocol has no dedicated error code for them.
+var notFoundMessages = []string{"doesn't exist", "does not exist", "unconfigured table"}
+
+// anonymousMessage is a fragment of the message of unauthorized errors returned to anonymous clients
+// of clusters that do not authenticate them.
+const anonymousMessage = "not anonymous"
+
+// unsupportedAuthenticatorMessage is a fragment of the error of the driver returned
+// when the cluster asks for an authenticator it does not support.
+const unsupportedAuthenticatorMessage = "not supported"
+
+// classifyError returns the class of the error of an executed statement.
+func classifyError(err error) errorClass {
+	if err == nil {
+		return errorClassOther
+	}
+	var hostErrs hostErrors
+	if errors.As(err, &hostErrs) {
+		return hostErrs.class()
+	}
+	var codedErr response.CodedError
+	if !errors.As(err, &codedErr) {
+		var netErr net.Error
+		if errors.As(err, &netErr) || errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+			return errorClassConnection
+		}
+		if msg := err.Error(); strings.Contains(msg, "authenticator") && strings.Contains(msg, unsupportedAuthenticatorMessage) {
+			return errorClassUnsupportedAuthenticator
+		}
+		return errorClassOther
+	}
+	switch codedErr.ErrorCode() {
+	case frame.ErrCodeCredentials:
+		return errorClassCredentials
+	case frame.ErrCodeUnauthorized:
+		if strings.Contains(codedErr.Error(), anonymousMessage) {
+			return errorClassAuthNotEnabled
+		}
+		return errorClassUnauthorized
+	case frame.ErrCodeSyntax:
+		return errorClassSyntax
+	case frame.ErrCodeUnavailable, frame.ErrCodeOverloaded, frame.ErrCodeBootstrapping:
+		return errorClassUnavailable
+	case frame.ErrCodeReadTimeout, frame.ErrCodeWriteTimeout:
+		return errorClassTimeout
+	case frame.ErrCodeAlreadyExists:
+		return errorClassAlreadyExists
+	case frame.ErrCodeInvalid:
+		msg := strings.ToLower(codedErr.Error())
+		for _, fragment := range notFoundMessages {
+			if strings.Contains(msg, fragment) {
+				return errorClassNotFound
+			}
+		}
+		return errorClassInvalid
+	default:
+		return errorClassOther
+	}
+}
+
+// isNotFoundError reports whether err means that an object referenced by the statement does not exist.
+func isNotFoundError(err error) bool {
+	return classifyError(err) == errorClassNotFound
+}
+
+// cqlErrorDiagnostic returns an error diagnostic for a failed statement.
+// The class of err is appended to the summary and its remediation to the detail.
+// If connection to all hosts failed, the error of every host is listed.
+func cqlErrorDiagnostic(summary, detail string, err error) diag.Diagnostic {
+	var hostErrs hostErrors
+	if errors.As(err, &hostErrs) {
+		title, hosts := describeConnectionErrors(hostErrs)
+		if title == "" {
+			title = errorClasses[errorClassConnection].title
+		}
+		return diag.NewErrorDiagnostic(fmt.Sprintf("%s: %s", summary, title),
+			fmt.Sprintf("%s\n\nConnection to all of the hosts failed:%s", detail, hosts))
+	}
+	info, ok := errorClasses[classifyError(err)]
+	if !ok {
+		return diag.NewErrorDiagnostic(summary, fmt.Sprintf("%s\n\n%s", detail, err))
+	}
+	return diag.NewErrorDiagnostic(fmt.Sprintf("%s: %s", summary, info.title),
+		fmt.Sprintf("%s\n\n%s\n\n%s", detail, err, info.remediation))
+}
+
+// hostError is the error of connecting to a host.
+type hostError struct {
+	hostport string
+	err      error
+}
+
+// hostErrors are the errors of connecting to several hosts, in the order of the hosts.
+type hostErrors []hostError
+
+func (e hostErrors) Error() string {
+	var sb strings.Builder
+	sb.WriteString("unable to connect to any host:")
+	for _, he := range e {
+		fmt.Fprintf(&sb, "\n  %s: %s", he.hostport, he.err)
+	}
+	return sb.String()
+}
+
+// class returns the class shared by the errors of all hosts, errorClassConnection if they differ.
+func (e hostErrors) class() errorClass {
+	if len(e) == 0 {
+		return errorClassConnection
+	}
+	class := classifyError(e[0].err)
+	for _, he := range e[1:] {
+		if classifyError(he.err) != class {
+			return errorClassConnection
+		}
+	}
+	return class
+}
+
+// describeConnectionErrors describes the errors of hosts that could not be connected to.
+// Every host is named together with the class of its error, followed by the remediation of the classes.
+// The title is the class shared by all the errors, empty if they differ, so that bad credentials
+// are not mistaken for network problems.
+func describeConnectionErrors(errs hostErrors) (title, detail string) {
+	var sb strings.Builder
+	classes := make(map[errorClass]struct{})
+	for _, he := range errs {
+		class := classifyError(he.err)
+		classes[class] = struct{}{}
+		if info, ok := errorClasses[class]; ok {
+			fmt.Fprintf(&sb, "\n  %s (%s): %s", he.hostport, info.title, he.err)
+		} else {
+			fmt.Fprintf(&sb, "\n  %s: %s", he.hostport, he.err)
+		}
+	}
+	for _, class := range []errorClass{errorClassCredentials, errorClassUnsupportedAuthenticator, errorClassConnection} {
+		if _, ok := classes[class]; ok {
+			sb.WriteString("\n\n" + errorClasses[class].remediation)
+		}
+	}
+	if len(classes) == 1 {
+		for class := range classes {
+			title = errorClasses[class].title
+		}
+	}
+	return title, sb.String()
+}
@@ -0,0 +1,103 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"testing"
+
+	"github.com/scylladb/scylla-go-driver/frame"
+	"github.com/scylladb/scylla-go-driver/frame/response"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClassifyError(t *testing.T) {
+	tests := []struct {
+		err  error
+		want errorClass
+	}{
+		{response.ScyllaError{Code: frame.ErrCodeUnauthorized, Message: "User app has no CREATE permission"}, errorClassUnauthorized},
+		{response.ScyllaError{Code: frame.ErrCodeSyntax, Message: "line 1:7 no viable alternative"}, errorClassSyntax},
+		{response.ScyllaError{Code: frame.ErrCodeUnavailable, Message: "Cannot achieve consistency level"}, errorClassUnavailable},
+		{response.ScyllaError{Code: frame.ErrCodeWriteTimeout, Message: "Operation timed out"}, errorClassTimeout},
+		{response.ScyllaError{Code: frame.ErrCodeAlreadyExists, Message: "Keyspace ks already exists"}, errorClassAlreadyExists},
+		{response.ScyllaError{Code: frame.ErrCodeInvalid, Message: "Role app doesn't exist."}, errorClassNotFound},
+		{response.ScyllaError{Code: frame.ErrCodeInvalid, Message: "unconfigured table roles"}, errorClassNotFound},
+		{response.ScyllaError{Code: frame.ErrCodeInvalid, Message: "Invalid shares value"}, errorClassInvalid},
+		{fmt.Errorf("wrapped: %w", response.ScyllaError{Code: frame.ErrCodeInvalid, Message: "Keyspace ks does not exist"}),
+			errorClassNotFound},
+		{response.ScyllaError{Code: frame.ErrCodeCredentials, Message: "Username and/or password are incorrect"},
+			errorClassCredentials},
+		{response.ScyllaError{Code: frame.ErrCodeUnauthorized,
+			Message: "You have to be logged in and not anonymous to perform this request"}, errorClassAuthNotEnabled},
+		{fmt.Errorf("startup: %w", fmt.Errorf(`authenticator "com.example.KerberosAuthenticator" not supported`)),
+			errorClassUnsupportedAuthenticator},
+		{&net.OpError{Op: "dial", Net: "tcp", Err: fmt.Errorf("connection refused")}, errorClassConnection},
+		{io.EOF, errorClassConnection},
+		{context.Canceled, errorClassOther},
+	}
+	for _, test := range tests {
+		assert.Equal(t, test.want, classifyError(test.err), "%v", test.err)
+	}
+}
+
+func TestCQLErrorDiagnostic(t *testing.T) {
+	d := cqlErrorDiagnostic("Error revoking", `REVOKE SELECT ON KEYSPACE "ks" FROM "app"`,
+		response.ScyllaError{Code: frame.ErrCodeUnauthorized, Message: "denied"})
+	assert.Equal(t, "Error revoking: permission denied", d.Summary())
+	assert.Contains(t, d.Detail(), `REVOKE SELECT ON KEYSPACE "ks" FROM "app"`)
+	assert.Contains(t, d.Detail(), "denied")
+	assert.Contains(t, d.Detail(), errorClasses[errorClassUnauthorized].remediation)
+
+	d = cqlErrorDiagnostic("Query error", "Unable to read role info.", fmt.Errorf("malformed result"))
+	assert.Equal(t, "Query error", d.Summary())
+	assert.Equal(t, "Unable to read role info.\n\nmalformed result", d.Detail())
+}
+
+func TestDescribeConnectionErrors(t *testing.T) {
+	credentialsErr := fmt.Errorf("startup: %w", response.ScyllaError{Code: frame.ErrCodeCredentials, Message: "Bad credentials"})
+	networkErr := &net.OpError{Op: "dial", Net: "tcp", Err: fmt.Errorf("connection refused")}
+
+	title, detail := describeConnectionErrors(hostErrors{
+		{hostport: "10.0.0.1:9042", err: credentialsErr},
+		{hostport: "10.0.0.3:9042", err: credentialsErr},
+	})
+	assert.Equal(t, "bad credentials", title)
+	assert.Contains(t, detail, "10.0.0.1:9042 (bad credentials): startup: ")
+	assert.Contains(t, detail, "10.0.0.3:9042 (bad credentials): startup: ")
+	assert.Contains(t, detail, errorClasses[errorClassCredentials].remediation)
+	assert.NotContains(t, detail, errorClasses[errorClassConnection].remediation)
+
+	title, detail = describeConnectionErrors(hostErrors{
+		{hostport: "10.0.0.1:9042", err: credentialsErr},
+		{hostport: "10.0.0.2:9042", err: networkErr},
+	})
+	assert.Empty(t, title)
+	assert.Contains(t, detail, "10.0.0.2:9042 (connection failed): dial tcp: connection refused")
+	assert.Contains(t, detail, errorClasses[errorClassCredentials].remediation)
+	assert.Contains(t, detail, errorClasses[errorClassConnection].remediation)
+}
+
+func TestHostErrors(t *testing.T) {
+	credentialsErr := response.ScyllaError{Code: frame.ErrCodeCredentials, Message: "Bad credentials"}
+	networkErr := &net.OpError{Op: "dial", Net: "tcp", Err: fmt.Errorf("connection refused")}
+
+	errs := hostErrors{
+		{hostport: "10.0.0.1:9042", err: credentialsErr},
+		{hostport: "10.0.0.2:9042", err: credentialsErr},
+	}
+	assert.Equal(t, errorClassCredentials, classifyError(fmt.Errorf("connect: %w", errs)))
+	assert.Equal(t, fmt.Sprintf("unable to connect to any host:\n  10.0.0.1:9042: %s\n  10.0.0.2:9042: %s", credentialsErr, credentialsErr),
+		errs.Error())
+
+	errs = append(errs, hostError{hostport: "10.0.0.3:9042", err: networkErr})
+	assert.Equal(t, errorClassConnection, classifyError(errs))
+
+	d := cqlErrorDiagnostic("Error creating role", `CREATE ROLE "app"`, fmt.Errorf("connect: %w", errs))
+	assert.Equal(t, "Error creating role: connection failed", d.Summary())
+	assert.Contains(t, d.Detail(), `CREATE ROLE "app"`)
+	for _, he := range errs {
+		assert.Contains(t, d.Detail(), he.hostport)
+	}
+}
@@ -0,0 +1,873 @@
+package provider
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+	"net"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/scylladb/scylla-go-driver/frame"
+	"github.com/scylladb/scylla-go-driver/frame/response"
+	"github.com/scylladb/scylla-go-driver/transport"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeCluster is an in-memory CQLExecutor that understands the statements issued by the resources
+// for roles, permissions and service levels, backed by canned system tables.
+// It lets resource logic be tested without a running cluster.
+type fakeCluster struct {
+	mu sync.Mutex
+
+	// roles by name.
+	roles map[string]*fakeRole
+
+	// permissions of roles, keyed by role and then by resource in the form returned by LIST PERMISSIONS.
+	permissions map[string]map[string]map[string]struct{}
+
+	// serviceLevels by name.
+	serviceLevels map[string]*fakeServiceLevel
+
+	// attached maps roles to the service levels attached to them.
+	attached map[string]string
+
+	// keyspaces holds the names of keyspaces.
+	keyspaces map[string]struct{}
+
+	// tables holds the tables in the form keyspace.table.
+	tables map[string]struct{}
+
+	// views holds the materialized views in the form keyspace.view.
+	views map[string]*fakeView
+
+	// executed holds all statements in the order they were executed.
+	executed []string
+
+	// denyListAll rejects listing permissions of all roles, as for a role that is not a superuser.
+	denyListAll bool
+
+	// denyListRoles rejects LIST ROLES, so that roles are read from the role tables.
+	denyListRoles bool
+
+	// user is the connected role, if set. Unless it is a superuser, LIST ROLES returns only the user
+	// and the roles granted to it, and the role tables cannot be read.
+	user string
+}
+
+type fakeRole struct {
+	login      bool
+	superuser  bool
+	saltedHash string
+	memberOf   map[string]struct{}
+}
+
+type fakeView struct {
+	baseTable   string
+	includeAll  bool
+	whereClause string
+	columns     []string
+}
+
+type fakeServiceLevel struct {
+	options map[string]string
+}
+
+func newFakeCluster() *fakeCluster {
+	return &fakeCluster{
+		roles:         make(map[string]*fakeRole),
+		permissions:   make(map[string]map[string]map[string]struct{}),
+		serviceLevels: make(map[string]*fakeServiceLevel),
+		attached:      make(map[string]string),
+		keyspaces:     make(map[string]struct{}),
+		tables:        make(map[string]struct{}),
+		views:         make(map[string]*fakeView),
+	}
+}
+
+// provider returns a provider executing statements on the fake cluster.
+func (c *fakeCluster) provider() provider {
+	return provider{
+		executor:        c,
+		consistency:     frame.LOCALQUORUM,
+		authConsistency: frame.LOCALQUORUM,
+		roleLocks:       &keyedMutex{},
+		permissions:     &permissionCache{},
+		schemaObjects:   &schemaCache{},
+		editions:        &editionCache{},
+		configured:      true,
+	}
+}
+
+// addRole adds a role as if it was created outside of Terraform.
+func (c *fakeCluster) addRole(name string) *fakeRole {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	r := &fakeRole{memberOf: make(map[string]struct{})}
+	c.roles[name] = r
+	return r
+}
+
+// role returns the role or nil if it does not exist.
+func (c *fakeCluster) role(name string) *fakeRole {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.roles[name]
+}
+
+// hasPermission reports whether the permission on the resource, in the form returned by LIST PERMISSIONS,
+// is granted directly to the role.
+func (c *fakeCluster) hasPermission(role, resource, permission string) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	_, ok := c.permissions[role][resource][permission]
+	return ok
+}
+
+// revoke removes a permission as if it was revoked outside of Terraform.
+func (c *fakeCluster) revoke(role, resource, permission string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.permissions[role][resource], permission)
+}
+
+const fakeName = `"((?:[^"]|"")*)"`
+
+type fakeHandler func(c *fakeCluster, m []string, values []frame.CqlValue) (transport.QueryResult, error)
+
+var fakeStatements = []struct {
+	re      *regexp.Regexp
+	handler fakeHandler
+}{
+	{regexp.MustCompile(`^CREATE ROLE (IF NOT EXISTS )?` + fakeName + `(?: WITH (.*))?$`), (*fakeCluster).createRole},
+	{regexp.MustCompile(`^ALTER ROLE ` + fakeName + `(?: WITH (.*))?$`), (*fakeCluster).alterRole},
+	{regexp.MustCompile(`^DROP ROLE ` + fakeName + `$`), (*fakeCluster).dropRole},
+	{regexp.MustCompile(`^GRANT ` + fakeName + ` TO ` + fakeName + `$`), (*fakeCluster).grantRole},
+	{regexp.MustCompile(`^REVOKE ` + fakeName + ` FROM ` + fakeName + `$`), (*fakeCluster).revokeRole},
+	{regexp.MustCompile(`^LIST ROLES OF ` + fakeName + ` NORECURSIVE$`), (*fakeCluster).listRoles},
+	{regexp.MustCompile(`^LIST ROLES$`), (*fakeCluster).listAllRoles},
+	{regexp.MustCompile(`^SELECT can_login, is_superuser, member_of, salted_hash FROM (\S+) WHERE role = \?$`), (*fakeCluster).selectRole},
+	{regexp.MustCompile(`^SELECT role, can_login, is_superuser FROM (\S+)$`), (*fakeCluster).selectRoles},
+	{regexp.MustCompile(`^GRANT (\w+) ON (.+) TO ` + fakeName + `$`), (*fakeCluster).grantPermission},
+	{regexp.MustCompile(`^REVOKE (\w+) ON (.+) FROM ` + fakeName + `$`), (*fakeCluster).revokePermission},
+	{regexp.MustCompile(`^LIST (\w+) PERMISSION ON (.+) OF ` + fakeName + ` NORECURSIVE$`), (*fakeCluster).listPermission},
+	{regexp.MustCompile(`^LIST ALL PERMISSIONS OF ` + fakeName + ` NORECURSIVE$`), (*fakeCluster).listAllPermissions},
+	{regexp.MustCompile(`^LIST ALL PERMISSIONS$`), (*fakeCluster).listAllPermissionsOfAllRoles},
+	{regexp.MustCompile(`^LIST ATTACHED SERVICE LEVEL OF ` + fakeName + `$`), (*fakeCluster).listAttached},
+	{regexp.MustCompile(`^ATTACH SERVICE LEVEL ` + fakeName + ` TO ` + fakeName + `$`), (*fakeCluster).attach},
+	{regexp.MustCompile(`^DETACH SERVICE LEVEL FROM ` + fakeName + `$`), (*fakeCluster).detach},
+	{regexp.MustCompile(`^CREATE SERVICE LEVEL (IF NOT EXISTS )?` + fakeName + `(?: WITH (.*))?$`), (*fakeCluster).createServiceLevel},
+	{regexp.MustCompile(`^ALTER SERVICE LEVEL ` + fakeName + `(?: WITH (.*))?$`), (*fakeCluster).alterServiceLevel},
+	{regexp.MustCompile(`^DROP SERVICE LEVEL ` + fakeName + `$`), (*fakeCluster).dropServiceLevel},
+	{regexp.MustCompile(`^LIST SERVICE LEVEL ` + fakeName + `$`), (*fakeCluster).listServiceLevel},
+	{regexp.MustCompile(`^SELECT keyspace_name FROM system_schema.keyspaces WHERE keyspace_name = \?$`), (*fakeCluster).selectKeyspace},
+	{regexp.MustCompile(`^SELECT table_name FROM system_schema.tables WHERE keyspace_name = \? AND table_name = \?$`), (*fakeCluster).selectTable},
+	{regexp.MustCompile(`^SELECT keyspace_name FROM system_schema.keyspaces$`), (*fakeCluster).selectKeyspaces},
+	{regexp.MustCompile(`^SELECT keyspace_name, table_name FROM system_schema.tables$`), (*fakeCluster).selectTables},
+	{regexp.MustCompile(`^SELECT view_name, base_table_name, include_all_columns, where_clause FROM system_schema.views WHERE keyspace_name = \?$`), (*fakeCluster).selectViews},
+	{regexp.MustCompile(`^SELECT column_name FROM system_schema.columns WHERE keyspace_name = \? AND table_name = \?$`), (*fakeCluster).selectColumns},
+	{regexp.MustCompile(`^SELECT rpc_address FROM system.local$`), (*fakeCluster).selectLocal},
+	{regexp.MustCompile(`^SELECT version FROM system.versions WHERE key = 'local'$`), (*fakeCluster).selectVersion},
+}
+
+func (c *fakeCluster) Execute(ctx context.Context, consistency frame.Consistency, query string,
+	values []frame.CqlValue) (transport.QueryResult, error) {
+	if err := ctx.Err(); err != nil {
+		return transport.QueryResult{}, err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.executed = append(c.executed, query)
+	for _, s := range fakeStatements {
+		if m := s.re.FindStringSubmatch(query); m != nil {
+			for i := range m {
+				m[i] = strings.ReplaceAll(m[i], `""`, `"`)
+			}
+			return s.handler(c, m, values)
+		}
+	}
+	return transport.QueryResult{}, fakeError(frame.ErrCodeSyntax, "fake cluster does not support statement: "+query)
+}
+
+func fakeError(code frame.ErrorCode, message string) error {
+	return response.ScyllaError{Code: code, Message: message}
+}
+
+func fakeRoleNotFound(name string) error {
+	return fakeError(frame.ErrCodeInvalid, fmt.Sprintf("Role %s doesn't exist.", name))
+}
+
+// parseFakeOptions parses options in the form of `KEY = value AND KEY = value`.
+func parseFakeOptions(s string) map[string]string {
+	options := make(map[string]string)
+	if s == "" {
+		return options
+	}
+	for _, option := range strings.Split(s, " AND ") {
+		key, value, _ := strings.Cut(option, " = ")
+		value = strings.TrimSpace(value)
+		if strings.HasPrefix(value, "'") {
+			value = strings.ReplaceAll(strings.Trim(value, "'"), "''", "'")
+		}
+		options[strings.ToLower(strings.TrimSpace(key))] = value
+	}
+	return options
+}
+
+func (c *fakeCluster) setRoleOptions(r *fakeRole, options map[string]string) {
+	if v, ok := options["login"]; ok {
+		r.login = v == "true"
+	}
+	if v, ok := options["superuser"]; ok {
+		r.superuser = v == "true"
+	}
+	if v, ok := options["password"]; ok {
+		// Not a real hash, but it changes with every password change like a salted one.
+		r.saltedHash = fmt.Sprintf("$fake$%d$%s", len(c.executed), v)
+	}
+}
+
+func (c *fakeCluster) createRole(m []string, _ []frame.CqlValue) (transport.QueryResult, error) {
+	if _, ok := c.roles[m[2]]; ok {
+		if m[1] != "" {
+			return transport.QueryResult{}, nil
+		}
+		return transport.QueryResult{}, fakeError(frame.ErrCodeInvalid, fmt.Sprintf("Role %s already exists", m[2]))
+	}
+	r := &fakeRole{memberOf: make(map[string]struct{})}
+	c.setRoleOptions(r, parseFakeOptions(m[3]))
+	c.roles[m[2]] = r
+	return transport.QueryResult{}, nil
+}
+
+func (c *fakeCluster) alterRole(m []string, _ []frame.CqlValue) (transport.QueryResult, error) {
+	r, ok := c.roles[m[1]]
+	if !ok {
+		return transport.QueryResult{}, fakeRoleNotFound(m[1])
+	}
+	if m[2] == "" {
+		return transport.QueryResult{}, fakeError(frame.ErrCodeSyntax, "line 1:0 no viable alternative at input '<EOF>'")
+	}
+	c.setRoleOptions(r, parseFakeOptions(m[2]))
+	return transport.QueryResult{}, nil
+}
+
+func (c *fakeCluster) dropRole(m []string, _ []frame.CqlValue) (transport.QueryResult, error) {
+	if _, ok := c.roles[m[1]]; !ok {
+		return transport.QueryResult{}, fakeRoleNotFound(m[1])
+	}
+	delete(c.roles, m[1])
+	delete(c.permissions, m[1])
+	delete(c.attached, m[1])
+	for _, r := range c.roles {
+		delete(r.memberOf, m[1])
+	}
+	return transport.QueryResult{}, nil
+}
+
+func (c *fakeCluster) grantRole(m []string, _ []frame.CqlValue) (transport.QueryResult, error) {
+	for _, name := range m[1:] {
+		if _, ok := c.roles[name]; !ok {
+			return transport.QueryResult{}, fakeRoleNotFound(name)
+		}
+	}
+	c.roles[m[2]].memberOf[m[1]] = struct{}{}
+	return transport.QueryResult{}, nil
+}
+
+func (c *fakeCluster) revokeRole(m []string, _ []frame.CqlValue) (transport.QueryResult, error) {
+	r, ok := c.roles[m[2]]
+	if !ok {
+		return transport.QueryResult{}, fakeRoleNotFound(m[2])
+	}
+	delete(r.memberOf, m[1])
+	return transport.QueryResult{}, nil
+}
+
+func (c *fakeCluster) listRoles(m []string, _ []frame.CqlValue) (transport.QueryResult, error) {
+	if c.denyListRoles {
+		return transport.QueryResult{}, fakeError(frame.ErrCodeUnauthorized, "LIST ROLES is not allowed")
+	}
+	r, ok := c.roles[m[1]]
+	if !ok {
+		return transport.QueryResult{}, fakeRoleNotFound(m[1])
+	}
+	names := []string{m[1]}
+	for name := range r.memberOf {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	result := transport.QueryResult{
+		ColSpec: []frame.ColumnSpec{{Name: "role"}, {Name: "super"}, {Name: "login"}},
+	}
+	for _, name := range names {
+		role := c.roles[name]
+		result.Rows = append(result.Rows, frame.Row{
+			fakeText(name), frame.CqlFromBoolean(role.superuser), frame.CqlFromBoolean(role.login),
+		})
+	}
+	return result, nil
+}
+
+func (c *fakeCluster) listAllRoles([]string, []frame.CqlValue) (transport.QueryResult, error) {
+	if c.denyListRoles {
+		return transport.QueryResult{}, fakeError(frame.ErrCodeUnauthorized, "LIST ROLES is not allowed")
+	}
+	var names []string
+	if c.restrictedUser() {
+		names = append(names, c.user)
+		for name := range c.roles[c.user].memberOf {
+			names = append(names, name)
+		}
+	} else {
+		for name := range c.roles {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	result := transport.QueryResult{
+		ColSpec: []frame.ColumnSpec{{Name: "role"}, {Name: "super"}, {Name: "login"}},
+	}
+	for _, name := range names {
+		role := c.roles[name]
+		result.Rows = append(result.Rows, frame.Row{
+			fakeText(name), frame.CqlFromBoolean(role.superuser), frame.CqlFromBoolean(role.login),
+		})
+	}
+	return result, nil
+}
+
+func (c *fakeCluster) selectRoles(m []string, _ []frame.CqlValue) (transport.QueryResult, error) {
+	if m[1] != "system_auth.roles" {
+		return transport.QueryResult{}, fakeError(frame.ErrCodeInvalid, "unconfigured table "+m[1])
+	}
+	if c.restrictedUser() {
+		return transport.QueryResult{}, fakeError(frame.ErrCodeUnauthorized,
+			fmt.Sprintf("User %s has no SELECT permission on <table %s>", c.user, m[1]))
+	}
+	var names []string
+	for name := range c.roles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	result := transport.QueryResult{
+		ColSpec: []frame.ColumnSpec{{Name: "role"}, {Name: "can_login"}, {Name: "is_superuser"}},
+	}
+	for _, name := range names {
+		role := c.roles[name]
+		result.Rows = append(result.Rows, frame.Row{
+			fakeText(name), frame.CqlFromBoolean(role.login), frame.CqlFromBoolean(role.superuser),
+		})
+	}
+	return result, nil
+}
+
+// restrictedUser reports whether the connected role is set and is not a superuser.
+func (c *fakeCluster) restrictedUser() bool {
+	if c.user == "" {
+		return false
+	}
+	r, ok := c.roles[c.user]
+	return !ok || !r.superuser
+}
+
+func (c *fakeCluster) selectRole(m []string, values []frame.CqlValue) (transport.QueryResult, error) {
+	// Roles are stored in system_auth, like in Scylla before auth was moved to Raft.
+	if m[1] != "system_auth.roles" {
+		return transport.QueryResult{}, fakeError(frame.ErrCodeInvalid, "unconfigured table "+m[1])
+	}
+	name, err := values[0].AsText()
+	if err != nil {
+		return transport.QueryResult{}, err
+	}
+	result := transport.QueryResult{
+		ColSpec: []frame.ColumnSpec{{Name: "can_login"}, {Name: "is_superuser"}, {Name: "member_of"}, {Name: "salted_hash"}},
+	}
+	r, ok := c.roles[name]
+	if !ok {
+		return result, nil
+	}
+	var memberOf []string
+	for parent := range r.memberOf {
+		memberOf = append(memberOf, parent)
+	}
+	sort.Strings(memberOf)
+	result.Rows = append(result.Rows, frame.Row{
+		frame.CqlFromBoolean(r.login), frame.CqlFromBoolean(r.superuser), fakeTextSet(memberOf), fakeText(r.saltedHash),
+	})
+	return result, nil
+}
+
+var fakeResources = []struct {
+	re     *regexp.Regexp
+	format string
+}{
+	{regexp.MustCompile(`^ALL KEYSPACES$`), "<all keyspaces>"},
+	{regexp.MustCompile(`^KEYSPACE ` + fakeName + `$`), "<keyspace %s>"},
+	{regexp.MustCompile(`^(?:TABLE )?` + fakeName + `\.` + fakeName + `$`), "<table %s.%s>"},
+	{regexp.MustCompile(`^ALL FUNCTIONS$`), "<all functions>"},
+	{regexp.MustCompile(`^ALL FUNCTIONS IN KEYSPACE ` + fakeName + `$`), "<all functions in %s>"},
+}
+
+// fakeListResource converts a resource from a GRANT statement to the form returned by LIST PERMISSIONS.
+func fakeListResource(resource string) (string, error) {
+	for _, r := range fakeResources {
+		if m := r.re.FindStringSubmatch(resource); m != nil {
+			args := make([]interface{}, len(m)-1)
+			for i := range args {
+				// Names are quoted, so they are case-sensitive.
+				args[i] = strings.ReplaceAll(m[i+1], `""`, `"`)
+			}
+			return fmt.Sprintf(r.format, args...), nil
+		}
+	}
+	return "", fakeError(frame.ErrCodeSyntax, "fake cluster does not support resource: "+resource)
+}
+
+func (c *fakeCluster) grantPermission(m []string, _ []frame.CqlValue) (transport.QueryResult, error) {
+	if _, ok := c.roles[m[3]]; !ok {
+		return transport.QueryResult{}, fakeRoleNotFound(m[3])
+	}
+	resource, err := fakeListResource(m[2])
+	if err != nil {
+		return transport.QueryResult{}, err
+	}
+	if c.permissions[m[3]] == nil {
+		c.permissions[m[3]] = make(map[string]map[string]struct{})
+	}
+	if c.permissions[m[3]][resource] == nil {
+		c.permissions[m[3]][resource] = make(map[string]struct{})
+	}
+	c.permissions[m[3]][resource][strings.ToUpper(m[1])] = struct{}{}
+	return transport.QueryResult{}, nil
+}
+
+func (c *fakeCluster) revokePermission(m []string, _ []frame.CqlValue) (transport.QueryResult, error) {
+	if _, ok := c.roles[m[3]]; !ok {
+		return transport.QueryResult{}, fakeRoleNotFound(m[3])
+	}
+	resource, err := fakeListResource(m[2])
+	if err != nil {
+		return transport.QueryResult{}, err
+	}
+	delete(c.permissions[m[3]][resource], strings.ToUpper(m[1]))
+	return transport.QueryResult{}, nil
+}
+
+func (c *fakeCluster) permissionRows(role string, match func(resource, permission string) bool) transport.QueryResult {
+	result := transport.QueryResult{
+		ColSpec: []frame.ColumnSpec{{Name: "role"}, {Name: "username"}, {Name: "resource"}, {Name: "permission"}},
+	}
+	var resources []string
+	for resource := range c.permissions[role] {
+		resources = append(resources, resource)
+	}
+	sort.Strings(resources)
+	for _, resource := range resources {
+		var permissions []string
+		for permission := range c.permissions[role][resource] {
+			permissions = append(permissions, permission)
+		}
+		sort.Strings(permissions)
+		for _, permission := range permissions {
+			if match(resource, permission) {
+				result.Rows = append(result.Rows, frame.Row{
+					fakeText(role), fakeText(role), fakeText(resource), fakeText(permission),
+				})
+			}
+		}
+	}
+	return result
+}
+
+func (c *fakeCluster) listPermission(m []string, _ []frame.CqlValue) (transport.QueryResult, error) {
+	if _, ok := c.roles[m[3]]; !ok {
+		return transport.QueryResult{}, fakeRoleNotFound(m[3])
+	}
+	resource, err := fakeListResource(m[2])
+	if err != nil {
+		return transport.QueryResult{}, err
+	}
+	return c.permissionRows(m[3], func(r, p string) bool {
+		return r == resource && p == strings.ToUpper(m[1])
+	}), nil
+}
+
+func (c *fakeCluster) listAllPermissions(m []string, _ []frame.CqlValue) (transport.QueryResult, error) {
+	if _, ok := c.roles[m[1]]; !ok {
+		return transport.QueryResult{}, fakeRoleNotFound(m[1])
+	}
+	return c.permissionRows(m[1], func(string, string) bool { return true }), nil
+}
+
+func (c *fakeCluster) listAllPermissionsOfAllRoles([]string, []frame.CqlValue) (transport.QueryResult, error) {
+	if c.denyListAll {
+		return transport.QueryResult{}, fakeError(frame.ErrCodeUnauthorized, "You have to be a superuser to list permissions of all roles")
+	}
+	var roles []string
+	for role := range c.permissions {
+		roles = append(roles, role)
+	}
+	sort.Strings(roles)
+	var result transport.QueryResult
+	for _, role := range roles {
+		rows := c.permissionRows(role, func(string, string) bool { return true })
+		result.ColSpec = rows.ColSpec
+		result.Rows = append(result.Rows, rows.Rows...)
+	}
+	return result, nil
+}
+
+func (c *fakeCluster) selectKeyspaces([]string, []frame.CqlValue) (transport.QueryResult, error) {
+	var names []string
+	for name := range c.keyspaces {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	result := transport.QueryResult{ColSpec: []frame.ColumnSpec{{Name: "keyspace_name"}}}
+	for _, name := range names {
+		result.Rows = append(result.Rows, frame.Row{fakeText(name)})
+	}
+	return result, nil
+}
+
+func (c *fakeCluster) selectTables([]string, []frame.CqlValue) (transport.QueryResult, error) {
+	var names []string
+	for name := range c.tables {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	result := transport.QueryResult{ColSpec: []frame.ColumnSpec{{Name: "keyspace_name"}, {Name: "table_name"}}}
+	for _, name := range names {
+		keyspace, table, _ := strings.Cut(name, ".")
+		result.Rows = append(result.Rows, frame.Row{fakeText(keyspace), fakeText(table)})
+	}
+	return result, nil
+}
+
+func (c *fakeCluster) selectKeyspace(_ []string, values []frame.CqlValue) (transport.QueryResult, error) {
+	keyspace := string(values[0].Value)
+	result := transport.QueryResult{ColSpec: []frame.ColumnSpec{{Name: "keyspace_name"}}}
+	if _, ok := c.keyspaces[keyspace]; ok {
+		result.Rows = append(result.Rows, frame.Row{fakeText(keyspace)})
+	}
+	return result, nil
+}
+
+func (c *fakeCluster) selectTable(_ []string, values []frame.CqlValue) (transport.QueryResult, error) {
+	table := string(values[1].Value)
+	result := transport.QueryResult{ColSpec: []frame.ColumnSpec{{Name: "table_name"}}}
+	if _, ok := c.tables[string(values[0].Value)+"."+table]; ok {
+		result.Rows = append(result.Rows, frame.Row{fakeText(table)})
+	}
+	return result, nil
+}
+
+func (c *fakeCluster) selectViews(_ []string, values []frame.CqlValue) (transport.QueryResult, error) {
+	keyspace := string(values[0].Value)
+	var names []string
+	for name := range c.views {
+		if ks, view, _ := strings.Cut(name, "."); ks == keyspace {
+			names = append(names, view)
+		}
+	}
+	// Reversed order makes sure that callers do not rely on the order of rows.
+	sort.Sort(sort.Reverse(sort.StringSlice(names)))
+	result := transport.QueryResult{ColSpec: []frame.ColumnSpec{
+		{Name: "view_name"}, {Name: "base_table_name"}, {Name: "include_all_columns"}, {Name: "where_clause"},
+	}}
+	for _, name := range names {
+		v := c.views[keyspace+"."+name]
+		result.Rows = append(result.Rows, frame.Row{
+			fakeText(name), fakeText(v.baseTable), frame.CqlFromBoolean(v.includeAll), fakeText(v.whereClause),
+		})
+	}
+	return result, nil
+}
+
+func (c *fakeCluster) selectColumns(_ []string, values []frame.CqlValue) (transport.QueryResult, error) {
+	result := transport.QueryResult{ColSpec: []frame.ColumnSpec{{Name: "column_name"}}}
+	if v, ok := c.views[string(values[0].Value)+"."+string(values[1].Value)]; ok {
+		for _, column := range v.columns {
+			result.Rows = append(result.Rows, frame.Row{fakeText(column)})
+		}
+	}
+	return result, nil
+}
+
+// fakeHost and fakeVersion describe the node answering the statements.
+const (
+	fakeHost    = "10.0.0.1"
+	fakeVersion = "5.1.0"
+)
+
+func (c *fakeCluster) selectLocal([]string, []frame.CqlValue) (transport.QueryResult, error) {
+	return transport.QueryResult{
+		ColSpec: []frame.ColumnSpec{{Name: "rpc_address"}},
+		Rows: []frame.Row{{
+			frame.CqlValue{Type: &frame.Option{ID: frame.InetID}, Value: net.ParseIP(fakeHost).To4()},
+		}},
+	}, nil
+}
+
+func (c *fakeCluster) selectVersion([]string, []frame.CqlValue) (transport.QueryResult, error) {
+	return transport.QueryResult{
+		ColSpec: []frame.ColumnSpec{{Name: "version"}},
+		Rows:    []frame.Row{{fakeText(fakeVersion)}},
+	}, nil
+}
+
+func (c *fakeCluster) listAttached(m []string, _ []frame.CqlValue) (transport.QueryResult, error) {
+	result := transport.QueryResult{
+		ColSpec: []frame.ColumnSpec{{Name: "role"}, {Name: "service_level"}},
+	}
+	if sl, ok := c.attached[m[1]]; ok {
+		result.Rows = append(result.Rows, frame.Row{fakeText(m[1]), fakeText(sl)})
+	}
+	return result, nil
+}
+
+func (c *fakeCluster) attach(m []string, _ []frame.CqlValue) (transport.QueryResult, error) {
+	if _, ok := c.serviceLevels[m[1]]; !ok {
+		return transport.QueryResult{}, fakeError(frame.ErrCodeInvalid, fmt.Sprintf("Service Level %s doesn't exist", m[1]))
+	}
+	if _, ok := c.roles[m[2]]; !ok {
+		return transport.QueryResult{}, fakeRoleNotFound(m[2])
+	}
+	c.attached[m[2]] = m[1]
+	return transport.QueryResult{}, nil
+}
+
+func (c *fakeCluster) detach(m []string, _ []frame.CqlValue) (transport.QueryResult, error) {
+	delete(c.attached, m[1])
+	return transport.QueryResult{}, nil
+}
+
+func (c *fakeCluster) createServiceLevel(m []string, _ []frame.CqlValue) (transport.QueryResult, error) {
+	if _, ok := c.serviceLevels[m[2]]; ok {
+		if m[1] != "" {
+			return transport.QueryResult{}, nil
+		}
+		return transport.QueryResult{}, fakeError(frame.ErrCodeInvalid, fmt.Sprintf("Service Level %s already exists", m[2]))
+	}
+	c.serviceLevels[m[2]] = &fakeServiceLevel{options: parseFakeOptions(m[3])}
+	return transport.QueryResult{}, nil
+}
+
+func (c *fakeCluster) alterServiceLevel(m []string, _ []frame.CqlValue) (transport.QueryResult, error) {
+	sl, ok := c.serviceLevels[m[1]]
+	if !ok {
+		return transport.QueryResult{}, fakeError(frame.ErrCodeInvalid, fmt.Sprintf("Service Level %s doesn't exist", m[1]))
+	}
+	for key, value := range parseFakeOptions(m[2]) {
+		if value == "null" {
+			delete(sl.options, key)
+			continue
+		}
+		sl.options[key] = value
+	}
+	return transport.QueryResult{}, nil
+}
+
+func (c *fakeCluster) dropServiceLevel(m []string, _ []frame.CqlValue) (transport.QueryResult, error) {
+	if _, ok := c.serviceLevels[m[1]]; !ok {
+		return transport.QueryResult{}, fakeError(frame.ErrCodeInvalid, fmt.Sprintf("Service Level %s doesn't exist", m[1]))
+	}
+	delete(c.serviceLevels, m[1])
+	return transport.QueryResult{}, nil
+}
+
+func (c *fakeCluster) listServiceLevel(m []string, _ []frame.CqlValue) (transport.QueryResult, error) {
+	sl, ok := c.serviceLevels[m[1]]
+	if !ok {
+		return transport.QueryResult{}, fakeError(frame.ErrCodeInvalid, fmt.Sprintf("Service Level %s doesn't exist", m[1]))
+	}
+	// Columns of open source Scylla, which has no shares.
+	result := transport.QueryResult{
+		ColSpec: []frame.ColumnSpec{{Name: "service_level"}, {Name: "timeout"}, {Name: "workload_type"}},
+	}
+	timeout := frame.CqlValue{Type: &frame.Option{ID: frame.DurationID}}
+	if v, ok := sl.options["timeout"]; ok {
+		var ms int64
+		if _, err := fmt.Sscanf(v, "%dms", &ms); err != nil {
+			return transport.QueryResult{}, fakeError(frame.ErrCodeInvalid, "invalid timeout "+v)
+		}
+		var err error
+		timeout, err = frame.CqlFromDuration(frame.Duration{Nanoseconds: ms * 1e6})
+		if err != nil {
+			return transport.QueryResult{}, err
+		}
+	}
+	workloadType := frame.CqlValue{Type: &frame.Option{ID: frame.VarcharID}}
+	if v, ok := sl.options["workload_type"]; ok && v != defaultWorkloadType {
+		workloadType = fakeText(v)
+	}
+	result.Rows = append(result.Rows, frame.Row{fakeText(m[1]), timeout, workloadType})
+	return result, nil
+}
+
+func fakeText(s string) frame.CqlValue {
+	return frame.CqlValue{Type: &frame.Option{ID: frame.VarcharID}, Value: []byte(s)}
+}
+
+func fakeTextSet(elems []string) frame.CqlValue {
+	v := frame.CqlValue{
+		Type: &frame.Option{ID: frame.SetID, Set: &frame.SetOption{Element: frame.Option{ID: frame.VarcharID}}},
+	}
+	if len(elems) == 0 {
+		return v
+	}
+	v.Value = binary.BigEndian.AppendUint32(nil, uint32(len(elems)))
+	for _, elem := range elems {
+		v.Value = binary.BigEndian.AppendUint32(v.Value, uint32(len(elem)))
+		v.Value = append(v.Value, elem...)
+	}
+	return v
+}
+
+// newTestState returns a state of the schema holding data.
+func newTestState(t *testing.T, schema tfsdk.Schema, data interface{}) tfsdk.State {
+	t.Helper()
+	state := tfsdk.State{Schema: schema, Raw: tftypes.NewValue(schema.TerraformType(context.Background()), nil)}
+	diags := state.Set(context.Background(), data)
+	require.False(t, diags.HasError(), "%v", diags)
+	return state
+}
+
+// newEmptyTestState returns a null state of the schema, as passed to Create.
+func newEmptyTestState(schema tfsdk.Schema) tfsdk.State {
+	return tfsdk.State{Schema: schema, Raw: tftypes.NewValue(schema.TerraformType(context.Background()), nil)}
+}
+
+// getTestState decodes the state into target.
+func getTestState(t *testing.T, state tfsdk.State, target interface{}) {
+	t.Helper()
+	diags := state.Get(context.Background(), target)
+	require.False(t, diags.HasError(), "%v", diags)
+}
+
+// testResource calls the operations of a resource of the provider with states of its schema,
+// so that tests show only the data they check.
+type testResource struct {
+	t        *testing.T
+	schema   tfsdk.Schema
+	resource tfsdk.Resource
+}
+
+// newTestResource returns a resource of the type connected through p, usually cluster.provider() of a fake cluster.
+func newTestResource(t *testing.T, typ tfsdk.ResourceType, p provider) *testResource {
+	t.Helper()
+	ctx := context.Background()
+	schema, diags := typ.GetSchema(ctx)
+	require.False(t, diags.HasError(), "%v", diags)
+	resource, diags := typ.NewResource(ctx, &p)
+	require.False(t, diags.HasError(), "%v", diags)
+	return &testResource{t: t, schema: schema, resource: resource}
+}
+
+// state returns a state of the resource holding data.
+func (r *testResource) state(data interface{}) tfsdk.State {
+	r.t.Helper()
+	return newTestState(r.t, r.schema, data)
+}
+
+// create creates the resource with data as both the configuration and the plan.
+func (r *testResource) create(data interface{}) tfsdk.CreateResourceResponse {
+	r.t.Helper()
+	config := r.state(data)
+	resp := tfsdk.CreateResourceResponse{State: newEmptyTestState(r.schema)}
+	r.resource.Create(context.Background(), tfsdk.CreateResourceRequest{
+		Config: tfsdk.Config{Schema: r.schema, Raw: config.Raw},
+		Plan:   tfsdk.Plan{Schema: r.schema, Raw: config.Raw},
+	}, &resp)
+	return resp
+}
+
+// read refreshes the state.
+func (r *testResource) read(state tfsdk.State) tfsdk.ReadResourceResponse {
+	resp := tfsdk.ReadResourceResponse{State: state}
+	r.resource.Read(context.Background(), tfsdk.ReadResourceRequest{State: state}, &resp)
+	return resp
+}
+
+// update updates the resource from the state to data as both the configuration and the plan.
+func (r *testResource) update(state tfsdk.State, data interface{}) tfsdk.UpdateResourceResponse {
+	r.t.Helper()
+	config := r.state(data)
+	resp := tfsdk.UpdateResourceResponse{State: state}
+	r.resource.Update(context.Background(), tfsdk.UpdateResourceRequest{
+		Config: tfsdk.Config{Schema: r.schema, Raw: config.Raw},
+		Plan:   tfsdk.Plan{Schema: r.schema, Raw: config.Raw},
+		State:  state,
+	}, &resp)
+	return resp
+}
+
+// delete deletes the resource.
+func (r *testResource) delete(state tfsdk.State) tfsdk.DeleteResourceResponse {
+	resp := tfsdk.DeleteResourceResponse{State: state}
+	r.resource.Delete(context.Background(), tfsdk.DeleteResourceRequest{State: state}, &resp)
+	return resp
+}
+
+// importState imports the resource with the given ID.
+func (r *testResource) importState(id string) tfsdk.ImportResourceStateResponse {
+	r.t.Helper()
+	importer, ok := r.resource.(tfsdk.ResourceWithImportState)
+	require.True(r.t, ok, "resource does not support import")
+	resp := tfsdk.ImportResourceStateResponse{State: newEmptyTestState(r.schema)}
+	importer.ImportState(context.Background(), tfsdk.ImportResourceStateRequest{ID: id}, &resp)
+	return resp
+}
+
+// modifyPlan plans the change from the state to data as both the configuration and the plan.
+func (r *testResource) modifyPlan(state tfsdk.State, data interface{}) tfsdk.ModifyResourcePlanResponse {
+	r.t.Helper()
+	modifier, ok := r.resource.(tfsdk.ResourceWithModifyPlan)
+	require.True(r.t, ok, "resource does not modify plans")
+	config := r.state(data)
+	resp := tfsdk.ModifyResourcePlanResponse{Plan: tfsdk.Plan{Schema: r.schema, Raw: config.Raw}}
+	modifier.ModifyPlan(context.Background(), tfsdk.ModifyResourcePlanRequest{
+		Config: tfsdk.Config{Schema: r.schema, Raw: config.Raw},
+		Plan:   tfsdk.Plan{Schema: r.schema, Raw: config.Raw},
+		State:  state,
+	}, &resp)
+	return resp
+}
+
+// testDataSource reads a data source of the provider with configurations of its schema.
+type testDataSource struct {
+	t          *testing.T
+	schema     tfsdk.Schema
+	dataSource tfsdk.DataSource
+}
+
+// newTestDataSource returns a data source of the type connected through p.
+func newTestDataSource(t *testing.T, typ tfsdk.DataSourceType, p provider) *testDataSource {
+	t.Helper()
+	ctx := context.Background()
+	schema, diags := typ.GetSchema(ctx)
+	require.False(t, diags.HasError(), "%v", diags)
+	dataSource, diags := typ.NewDataSource(ctx, &p)
+	require.False(t, diags.HasError(), "%v", diags)
+	return &testDataSource{t: t, schema: schema, dataSource: dataSource}
+}
+
+// read reads the data source with data as the configuration.
+func (d *testDataSource) read(data interface{}) tfsdk.ReadDataSourceResponse {
+	d.t.Helper()
+	config := newTestState(d.t, d.schema, data)
+	resp := tfsdk.ReadDataSourceResponse{State: newEmptyTestState(d.schema)}
+	d.dataSource.Read(context.Background(), tfsdk.ReadDataSourceRequest{
+		Config: tfsdk.Config{Schema: d.schema, Raw: config.Raw},
+	}, &resp)
+	return resp
+}
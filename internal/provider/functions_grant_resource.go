@@ -0,0 +1,210 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/kiwicom/terraform-provider-scylla/internal/qb"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces
+var _ tfsdk.ResourceType = functionsGrantResourceType{}
+var _ tfsdk.Resource = functionsGrantResource{}
+var _ tfsdk.ResourceWithUpgradeState = functionsGrantResource{}
+var _ tfsdk.ResourceWithImportState = functionsGrantResource{}
+var _ tfsdk.ResourceWithValidateConfig = functionsGrantResource{}
+var _ grantResourceData = &functionsGrantResourceData{}
+var _ grantTargetData = &functionsGrantResourceData{}
+
+type functionsGrantResourceType struct{}
+
+func (t functionsGrantResourceType) GetSchema(ctx context.Context) (tfsdk.Schema, diag.Diagnostics) {
+	return tfsdk.Schema{
+		// Version 1 added the timeouts block.
+		Version: 1,
+
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Manages grant on all functions, or all functions in a keyspace, for a single role",
+
+		Attributes: map[string]tfsdk.Attribute{
+			"keyspace": {
+				MarkdownDescription: "Name of the keyspace whose functions are granted. All functions are granted if not set. " +
+					identifierDescription,
+				Optional: true,
+				Type:     types.StringType,
+				Validators: []tfsdk.AttributeValidator{
+					keyspaceNameValidator(),
+				},
+				PlanModifiers: []tfsdk.AttributePlanModifier{
+					requiresReplaceIfNameChanged(),
+				},
+			},
+			"id": {
+				Computed:            true,
+				MarkdownDescription: "ID of the grant in the form `grantee/keyspace/permission`, or `grantee/permission` for all functions",
+				Type:                types.StringType,
+			},
+			"grantee": {
+				Required:            true,
+				MarkdownDescription: "The name of the role that will be granted privileges to the resource.",
+				Type:                types.StringType,
+				Validators: []tfsdk.AttributeValidator{
+					roleNameValidator(),
+				},
+				PlanModifiers: []tfsdk.AttributePlanModifier{
+					tfsdk.RequiresReplace(),
+				},
+			},
+			"permission": {
+				Required: true,
+				MarkdownDescription: `The permission that is granted.
+One of:
+
+* ALTER
+* AUTHORIZE
+* CREATE
+* DROP
+* EXECUTE`,
+				Type: types.StringType,
+			},
+		},
+		Blocks: map[string]tfsdk.Block{
+			"timeouts": timeoutsBlock(),
+		},
+	}, nil
+}
+
+func (t functionsGrantResourceType) NewResource(ctx context.Context, in tfsdk.Provider) (tfsdk.Resource, diag.Diagnostics) {
+	provider, diags := convertProviderType(in)
+
+	return functionsGrantResource{
+		provider: provider,
+	}, diags
+}
+
+type functionsGrantResourceData struct {
+	Keyspace   types.String `tfsdk:"keyspace"`
+	Grantee    types.String `tfsdk:"grantee"`
+	Id         types.String `tfsdk:"id"`
+	Permission types.String `tfsdk:"permission"`
+
+	Timeouts []timeoutsData `tfsdk:"timeouts"`
+}
+
+func (t *functionsGrantResourceData) resource() qb.CQL {
+	if t.Keyspace.IsNull() {
+		return "ALL FUNCTIONS"
+	}
+	return qb.CQL(fmt.Sprintf("ALL FUNCTIONS IN KEYSPACE %s", cqlName(t.Keyspace.Value)))
+}
+
+func (t *functionsGrantResourceData) listResource() string {
+	if t.Keyspace.IsNull() {
+		return "<all functions>"
+	}
+	return fmt.Sprintf("<all functions in %s>", qb.NormalizeName(t.Keyspace.Value))
+}
+
+func (t *functionsGrantResourceData) permission() qb.CQL {
+	return qb.CQL(t.Permission.Value)
+}
+
+func (t *functionsGrantResourceData) grantee() string {
+	return t.Grantee.Value
+}
+
+func (t *functionsGrantResourceData) target() (keyspace, table string) {
+	return qb.NormalizeName(t.Keyspace.Value), ""
+}
+
+func (t *functionsGrantResourceData) timeouts() []timeoutsData {
+	return t.Timeouts
+}
+
+func (t *functionsGrantResourceData) setId() {
+	if t.Keyspace.IsNull() {
+		t.Id = types.String{Value: fmt.Sprintf("%s/%s", t.Grantee.Value, strings.ToUpper(t.Permission.Value))}
+		return
+	}
+	t.Id = types.String{Value: fmt.Sprintf("%s/%s/%s", t.Grantee.Value, grantIdName(t.Keyspace.Value),
+		strings.ToUpper(t.Permission.Value))}
+}
+
+func (t *functionsGrantResourceData) validate() (diags diag.Diagnostics) {
+	if t.Keyspace.IsUnknown() {
+		diags.AddAttributeError(path.Root("keyspace"), "Keyspace missing",
+			"Keyspace must be known, omit it to grant all functions.")
+	}
+	if t.Grantee.IsNull() || t.Grantee.IsUnknown() {
+		diags.AddAttributeError(path.Root("grantee"), "Grantee missing",
+			"Grantee must be specified.")
+	}
+	if t.Permission.IsNull() || t.Permission.IsUnknown() || t.Permission.Value == "" {
+		diags.AddAttributeError(path.Root("permission"), "Permission missing",
+			"Permission must be specified.")
+	} else {
+		diags.Append(validatePermission(path.Root("permission"), t.Permission, functionPermissions, "functions")...)
+	}
+
+	return
+}
+
+type functionsGrantResource struct {
+	provider provider
+}
+
+func (r functionsGrantResource) UpgradeState(ctx context.Context) map[int64]tfsdk.ResourceStateUpgrader {
+	return map[int64]tfsdk.ResourceStateUpgrader{
+		0: upgradeStateAddTimeouts(),
+	}
+}
+
+var functionPermissions = map[string]struct{}{
+	"CREATE":    {},
+	"ALTER":     {},
+	"DROP":      {},
+	"AUTHORIZE": {},
+	"EXECUTE":   {},
+}
+
+func (r functionsGrantResource) ValidateConfig(ctx context.Context, req tfsdk.ValidateResourceConfigRequest, resp *tfsdk.ValidateResourceConfigResponse) {
+	var data functionsGrantResourceData
+
+	diags := req.Config.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(validatePermission(path.Root("permission"), data.Permission, functionPermissions, "functions")...)
+}
+
+func (r functionsGrantResource) Create(ctx context.Context, req tfsdk.CreateResourceRequest, resp *tfsdk.CreateResourceResponse) {
+	var data functionsGrantResourceData
+	r.provider.createGrant(ctx, req, resp, &data)
+}
+
+func (r functionsGrantResource) Read(ctx context.Context, req tfsdk.ReadResourceRequest, resp *tfsdk.ReadResourceResponse) {
+	var data functionsGrantResourceData
+	r.provider.readGrant(ctx, req, resp, &data)
+}
+
+func (r functionsGrantResource) Update(ctx context.Context, req tfsdk.UpdateResourceRequest, resp *tfsdk.UpdateResourceResponse) {
+	var plan, state functionsGrantResourceData
+	r.provider.updateGrant(ctx, req, resp, &plan, &state)
+}
+
+func (r functionsGrantResource) Delete(ctx context.Context, req tfsdk.DeleteResourceRequest, resp *tfsdk.DeleteResourceResponse) {
+	var data functionsGrantResourceData
+	r.provider.deleteGrant(ctx, req, resp, &data)
+}
+
+func (r functionsGrantResource) ImportState(ctx context.Context, req tfsdk.ImportResourceStateRequest, resp *tfsdk.ImportResourceStateResponse) {
+	if strings.Count(req.ID, "/") == 1 {
+		importGrant(ctx, req, resp, "grantee", "permission")
+		return
+	}
+	importGrant(ctx, req, resp, "grantee", "keyspace", "permission")
+}
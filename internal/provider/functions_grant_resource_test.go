@@ -0,0 +1,45 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFunctionsGrantResourceLifecycle(t *testing.T) {
+	tests := []struct {
+		keyspace types.String
+		resource string
+	}{
+		{keyspace: types.String{Null: true}, resource: "<all functions>"},
+		{keyspace: types.String{Value: "Shop"}, resource: "<all functions in shop>"},
+	}
+	for _, test := range tests {
+		t.Run(test.resource, func(t *testing.T) {
+			cluster := newFakeCluster()
+			cluster.addRole("app")
+			cluster.keyspaces["shop"] = struct{}{}
+			r := newTestResource(t, functionsGrantResourceType{}, cluster.provider())
+
+			createResp := r.create(&functionsGrantResourceData{
+				Keyspace:   test.keyspace,
+				Grantee:    types.String{Value: "app"},
+				Id:         types.String{Unknown: true},
+				Permission: types.String{Value: "execute"},
+			})
+			require.False(t, createResp.Diagnostics.HasError(), "%v", createResp.Diagnostics)
+			assert.True(t, cluster.hasPermission("app", test.resource, "EXECUTE"))
+
+			// The grant is found among the permissions listed by the server, so it stays in the state.
+			readResp := r.read(createResp.State)
+			require.False(t, readResp.Diagnostics.HasError(), "%v", readResp.Diagnostics)
+			assert.False(t, readResp.State.Raw.IsNull())
+
+			deleteResp := r.delete(readResp.State)
+			require.False(t, deleteResp.Diagnostics.HasError(), "%v", deleteResp.Diagnostics)
+			assert.False(t, cluster.hasPermission("app", test.resource, "EXECUTE"))
+		})
+	}
+}
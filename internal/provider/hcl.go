@@ -0,0 +1,93 @@
+package provider
+
+import (
+	"strconv"
+	"strings"
+)
+
+// hclBlock is a block of Terraform configuration, such as a resource, rendered by the export data source.
+type hclBlock struct {
+	// header is the block type and its labels, for example resource "scylla_role" "app".
+	header     string
+	attributes []hclAttribute
+}
+
+type hclAttribute struct {
+	name string
+	// value is an HCL expression, for example a quoted string.
+	value string
+}
+
+// newHCLBlock returns a block of the type with the quoted labels.
+func newHCLBlock(typ string, labels ...string) *hclBlock {
+	header := typ
+	for _, label := range labels {
+		header += " " + hclString(label)
+	}
+	return &hclBlock{header: header}
+}
+
+// set appends the attribute to the block.
+func (b *hclBlock) set(name, value string) {
+	b.attributes = append(b.attributes, hclAttribute{name: name, value: value})
+}
+
+// writeTo renders the block, aligning the equals signs of the attributes like terraform fmt does.
+func (b *hclBlock) writeTo(sb *strings.Builder) {
+	width := 0
+	for _, a := range b.attributes {
+		if len(a.name) > width {
+			width = len(a.name)
+		}
+	}
+	sb.WriteString(b.header)
+	sb.WriteString(" {\n")
+	for _, a := range b.attributes {
+		sb.WriteString("  ")
+		sb.WriteString(a.name)
+		sb.WriteString(strings.Repeat(" ", width-len(a.name)))
+		sb.WriteString(" = ")
+		sb.WriteString(a.value)
+		sb.WriteString("\n")
+	}
+	sb.WriteString("}\n")
+}
+
+// hclString returns the quoted HCL string literal of s. Template sequences are escaped,
+// so that the value is taken literally.
+func hclString(s string) string {
+	quoted := strconv.Quote(s)
+	quoted = strings.ReplaceAll(quoted, "${", "$${")
+	return strings.ReplaceAll(quoted, "%{", "%%{")
+}
+
+// hclStringList returns the HCL list literal of the strings.
+func hclStringList(items []string) string {
+	quoted := make([]string, len(items))
+	for i, item := range items {
+		quoted[i] = hclString(item)
+	}
+	return "[" + strings.Join(quoted, ", ") + "]"
+}
+
+// hclIdentifier turns s into a valid name of a resource, replacing characters that are not allowed by underscores.
+func hclIdentifier(s string) string {
+	var sb strings.Builder
+	for i, r := range strings.ToLower(s) {
+		switch {
+		case r >= 'a' && r <= 'z', r == '_', r == '-' && i > 0:
+			sb.WriteRune(r)
+		case r >= '0' && r <= '9':
+			if i == 0 {
+				sb.WriteRune('_')
+			}
+			sb.WriteRune(r)
+		default:
+			sb.WriteRune('_')
+		}
+	}
+	if sb.Len() == 0 {
+		return "_"
+	}
+	return sb.String()
+}
@@ -0,0 +1,127 @@
+package provider
+
+import (
+	"fmt"
+	"math/rand"
+	"net"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/scylladb/scylla-go-driver/transport"
+)
+
+// Strategies of the host_selection provider attribute.
+const (
+	// hostSelectionRoundRobin tries the contact points starting at a random one and moving to the next one
+	// on every reconnect, and spreads statements over the nodes in turn.
+	hostSelectionRoundRobin = "round_robin"
+	// hostSelectionRandom tries the contact points and sends statements to the nodes in random order.
+	hostSelectionRandom = "random"
+	// hostSelectionFixedOrder tries the contact points in the configured order and sends statements
+	// to the first available node, contact points first.
+	hostSelectionFixedOrder = "fixed_order"
+)
+
+var hostSelections = []string{hostSelectionRoundRobin, hostSelectionRandom, hostSelectionFixedOrder}
+
+// hostSelection orders the contact points when connecting and the nodes of the query plan of statements,
+// so that providers of many workspaces do not all load the same node.
+type hostSelection struct {
+	strategy string
+
+	// localDatacenter is the datacenter whose nodes the query plans start with, if set.
+	// The plans are then kept in the order of the driver, so that its nodes stay first.
+	localDatacenter string
+
+	// mu guards rand and next.
+	mu   sync.Mutex
+	rand *rand.Rand
+	// next is the position of the contact point tried first by the round robin strategy.
+	next int
+}
+
+func newHostSelection(strategy, localDatacenter string) (*hostSelection, error) {
+	switch strategy {
+	case hostSelectionRoundRobin, hostSelectionRandom, hostSelectionFixedOrder:
+	default:
+		return nil, fmt.Errorf("unsupported host selection %q, must be one of %s", strategy, hostSelections)
+	}
+	r := rand.New(rand.NewSource(time.Now().UnixNano()))
+	return &hostSelection{strategy: strategy, localDatacenter: localDatacenter, rand: r, next: r.Int()}, nil
+}
+
+// orderHosts returns the contact points in the order they are tried when connecting.
+func (h *hostSelection) orderHosts(hosts []string) []string {
+	ordered := make([]string, len(hosts))
+	copy(ordered, hosts)
+	if h == nil || len(hosts) == 0 {
+		return ordered
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	switch h.strategy {
+	case hostSelectionRoundRobin:
+		start := h.next % len(hosts)
+		h.next = start + 1
+		ordered = append(ordered[start:], ordered[:start]...)
+	case hostSelectionRandom:
+		h.rand.Shuffle(len(ordered), func(i, j int) { ordered[i], ordered[j] = ordered[j], ordered[i] })
+	}
+	return ordered
+}
+
+// orderNodes reorders the nodes of the query plan of a statement. The driver already rotates the plan
+// for every statement, which is the round robin strategy. The local datacenter takes precedence over
+// the strategy, the plan is not reordered when it is set.
+func (h *hostSelection) orderNodes(nodes []*transport.Node, hosts []string) []*transport.Node {
+	if h == nil || h.localDatacenter != "" {
+		return nodes
+	}
+	switch h.strategy {
+	case hostSelectionRandom:
+		h.mu.Lock()
+		h.rand.Shuffle(len(nodes), func(i, j int) { nodes[i], nodes[j] = nodes[j], nodes[i] })
+		h.mu.Unlock()
+	case hostSelectionFixedOrder:
+		// The driver does not expose addresses of the nodes, they are read from their connections.
+		rank := make(map[string]int, len(hosts))
+		for i, hostport := range hosts {
+			if host, _, err := net.SplitHostPort(hostport); err == nil {
+				rank[host] = i
+			}
+		}
+		addrs := make(map[*transport.Node]string, len(nodes))
+		for _, n := range nodes {
+			addrs[n] = nodeAddr(n)
+		}
+		sort.SliceStable(nodes, func(i, j int) bool {
+			ai, aj := addrs[nodes[i]], addrs[nodes[j]]
+			ri, iok := rank[ai]
+			rj, jok := rank[aj]
+			switch {
+			case iok && jok:
+				return ri < rj
+			case iok != jok:
+				return iok
+			default:
+				return ai < aj
+			}
+		})
+	}
+	return nodes
+}
+
+// nodeAddr returns the IP address of the node, empty if it has no open connection.
+func nodeAddr(n *transport.Node) string {
+	conn, err := n.LeastBusyConn()
+	if err != nil || conn == nil {
+		return ""
+	}
+	host, _, err := net.SplitHostPort(conn.RemoteAddr().String())
+	if err != nil {
+		return ""
+	}
+	return host
+}
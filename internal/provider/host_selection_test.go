@@ -0,0 +1,70 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/scylladb/scylla-go-driver/frame"
+	"github.com/scylladb/scylla-go-driver/transport"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHostSelectionOrderHosts(t *testing.T) {
+	hosts := []string{"10.0.0.1:9042", "10.0.0.2:9042", "10.0.0.3:9042"}
+
+	h, err := newHostSelection(hostSelectionFixedOrder, "")
+	require.NoError(t, err)
+	assert.Equal(t, hosts, h.orderHosts(hosts))
+	assert.Equal(t, hosts, h.orderHosts(hosts))
+
+	h, err = newHostSelection(hostSelectionRoundRobin, "")
+	require.NoError(t, err)
+	first := h.orderHosts(hosts)
+	assert.ElementsMatch(t, hosts, first)
+	second := h.orderHosts(hosts)
+	assert.Equal(t, append(first[1:], first[0]), second)
+
+	h, err = newHostSelection(hostSelectionRandom, "")
+	require.NoError(t, err)
+	assert.ElementsMatch(t, hosts, h.orderHosts(hosts))
+	assert.Equal(t, []string{"10.0.0.1:9042", "10.0.0.2:9042", "10.0.0.3:9042"}, hosts, "hosts must not be modified")
+
+	_, err = newHostSelection("first", "")
+	assert.EqualError(t, err, `unsupported host selection "first", must be one of [round_robin random fixed_order]`)
+}
+
+func TestHostSelectionOrderNodesLocalDatacenter(t *testing.T) {
+	nodes := make([]*transport.Node, 5)
+	for i := range nodes {
+		nodes[i] = &transport.Node{}
+	}
+	expected := append([]*transport.Node(nil), nodes...)
+
+	// The driver puts the nodes of the local datacenter first, which the strategy must not undo.
+	h, err := newHostSelection(hostSelectionRandom, "dc1")
+	require.NoError(t, err)
+	for i := 0; i < 10; i++ {
+		assert.Equal(t, expected, h.orderNodes(nodes, nil))
+	}
+}
+
+func TestSessionInitClusterOrdersHosts(t *testing.T) {
+	hosts := []string{"10.0.0.1:9042", "10.0.0.2:9042", "10.0.0.3:9042"}
+	h, err := newHostSelection(hostSelectionRoundRobin, "")
+	require.NoError(t, err)
+	h.next = 1
+
+	var contactPoints []string
+	s := &session{
+		hosts:         hosts,
+		hostSelection: h,
+		newCluster: func(ctx context.Context, cfg transport.ConnConfig, p transport.HostSelectionPolicy,
+			e []frame.EventType, hosts ...string) (*transport.Cluster, error) {
+			contactPoints = hosts
+			return nil, nil
+		},
+	}
+	require.NoError(t, s.initCluster(context.Background()))
+	assert.Equal(t, []string{"10.0.0.2:9042", "10.0.0.3:9042", "10.0.0.1:9042"}, contactPoints)
+}
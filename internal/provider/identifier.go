@@ -0,0 +1,43 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/kiwicom/terraform-provider-scylla/internal/qb"
+)
+
+// identifierDescription documents attributes holding names of keyspaces and tables.
+const identifierDescription = "Unquoted names are case-insensitive as in CQL, " +
+	"enclose the name in double quotes to refer to a case-sensitive one, for example `\"\\\"MyTable\\\"\"`."
+
+// cqlName returns the quoted name that an identifier from the configuration refers to.
+// Unquoted identifiers are lowercased, quoted ones are used as they are, see qb.NormalizeName.
+func cqlName(identifier string) qb.CQL {
+	return qb.QName(qb.NormalizeName(identifier))
+}
+
+// requiresReplaceIfNameChanged requires replacement of the resource when the identifier attribute
+// refers to another object, but not when only the case of an unquoted identifier changed.
+func requiresReplaceIfNameChanged() tfsdk.AttributePlanModifier {
+	return tfsdk.RequiresReplaceIf(
+		func(ctx context.Context, state, config attr.Value, _ path.Path) (bool, diag.Diagnostics) {
+			stateName, ok := state.(types.String)
+			if !ok {
+				return true, nil
+			}
+			configName, ok := config.(types.String)
+			if !ok || configName.IsUnknown() || configName.IsNull() || stateName.IsNull() {
+				return true, nil
+			}
+			return qb.NormalizeName(stateName.Value) != qb.NormalizeName(configName.Value), nil
+		},
+		"If the name changes other than in case of an unquoted identifier, Terraform will destroy and recreate the resource.",
+		"If the name changes other than in case of an unquoted identifier, Terraform will destroy and recreate the resource.",
+	)
+}
@@ -0,0 +1,53 @@
+package provider
+
+import (
+	"context"
+	"time"
+
+	"github.com/scylladb/scylla-go-driver/transport"
+)
+
+// keepaliveConn sends an OPTIONS request over conn every interval, so that load balancers and firewalls
+// do not drop the connection while it is idle. It returns once the connection is broken or done is closed.
+func keepaliveConn(conn *transport.Conn, interval time.Duration, done <-chan struct{}) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-done:
+			return
+		case <-ticker.C:
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), interval)
+		_, err := conn.Supported(ctx)
+		cancel()
+		if err != nil {
+			return
+		}
+	}
+}
+
+// keepaliveCluster sends an OPTIONS request to every node of the cluster each interval until done is closed.
+// Broken connections are reopened by the driver.
+func keepaliveCluster(cluster *transport.Cluster, interval time.Duration, done <-chan struct{}) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-done:
+			return
+		case <-ticker.C:
+		}
+		for _, n := range cluster.Topology().Nodes {
+			conn, err := n.LeastBusyConn()
+			if err != nil {
+				continue
+			}
+			ctx, cancel := context.WithTimeout(context.Background(), interval)
+			_, _ = conn.Supported(ctx)
+			cancel()
+		}
+	}
+}
@@ -0,0 +1,45 @@
+package provider
+
+import (
+	"testing"
+	"time"
+)
+
+func TestKeepaliveStopsWhenDone(t *testing.T) {
+	for name, keepalive := range map[string]func(done <-chan struct{}){
+		"conn": func(done <-chan struct{}) {
+			keepaliveConn(nil, time.Hour, done)
+		},
+		"cluster": func(done <-chan struct{}) {
+			keepaliveCluster(nil, time.Hour, done)
+		},
+	} {
+		done := make(chan struct{})
+		returned := make(chan struct{})
+		go func() {
+			keepalive(done)
+			close(returned)
+		}()
+		close(done)
+
+		select {
+		case <-returned:
+		case <-time.After(5 * time.Second):
+			t.Fatalf("%s: keepalive did not stop", name)
+		}
+	}
+}
+
+func TestSessionStopKeepalive(t *testing.T) {
+	s := &session{stopKeepalive: make(chan struct{})}
+	done := s.stopKeepalive
+
+	s.close()
+	select {
+	case <-done:
+	default:
+		t.Fatal("keepalive not stopped on close")
+	}
+	// Closing again does not panic.
+	s.close()
+}
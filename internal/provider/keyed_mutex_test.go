@@ -0,0 +1,53 @@
+package provider
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestKeyedMutex(t *testing.T) {
+	var m keyedMutex
+
+	unlockA := m.lock("a")
+
+	// Other keys are not blocked.
+	unlockB := m.lock("b")
+	unlockB()
+
+	locked := make(chan struct{})
+	go func() {
+		unlock := m.lock("a")
+		close(locked)
+		unlock()
+	}()
+
+	select {
+	case <-locked:
+		t.Fatal("key locked twice")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	unlockA()
+	<-locked
+}
+
+func TestKeyedMutex_Cleanup(t *testing.T) {
+	var m keyedMutex
+	var wg sync.WaitGroup
+	counter := 0
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			unlock := m.lock("role")
+			counter++
+			unlock()
+		}()
+	}
+	wg.Wait()
+	assert.Equal(t, 50, counter)
+	assert.Empty(t, m.locks)
+}
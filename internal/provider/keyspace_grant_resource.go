@@ -3,7 +3,6 @@ package provider
 import (
 	"context"
 	"fmt"
-	"sort"
 	"strings"
 
 	"github.com/hashicorp/terraform-plugin-framework/diag"
@@ -17,29 +16,46 @@ import (
 // Ensure provider defined types fully satisfy framework interfaces
 var _ tfsdk.ResourceType = keyspaceGrantResourceType{}
 var _ tfsdk.Resource = keyspaceGrantResource{}
+var _ tfsdk.ResourceWithUpgradeState = keyspaceGrantResource{}
 var _ tfsdk.ResourceWithImportState = keyspaceGrantResource{}
+var _ tfsdk.ResourceWithValidateConfig = keyspaceGrantResource{}
 var _ grantResourceData = &keyspaceGrantResourceData{}
+var _ grantTargetData = &keyspaceGrantResourceData{}
 
 type keyspaceGrantResourceType struct{}
 
 func (t keyspaceGrantResourceType) GetSchema(ctx context.Context) (tfsdk.Schema, diag.Diagnostics) {
 	return tfsdk.Schema{
+		// Version 1 added the timeouts block.
+		Version: 1,
+
 		// This description is used by the documentation generator and the language server.
 		MarkdownDescription: "Manages grant to a single table for a single role",
 
 		Attributes: map[string]tfsdk.Attribute{
 			"keyspace": {
-				MarkdownDescription: "Name of the keyspace where the table resides",
+				MarkdownDescription: "Name of the keyspace. " + identifierDescription,
 				Required:            true,
 				Type:                types.StringType,
+				Validators: []tfsdk.AttributeValidator{
+					keyspaceNameValidator(),
+				},
 				PlanModifiers: []tfsdk.AttributePlanModifier{
-					tfsdk.RequiresReplace(),
+					requiresReplaceIfNameChanged(),
 				},
 			},
+			"id": {
+				Computed:            true,
+				MarkdownDescription: "ID of the grant in the form `grantee/keyspace/permission`",
+				Type:                types.StringType,
+			},
 			"grantee": {
 				Required:            true,
 				MarkdownDescription: "The name of the role that will be granted privileges to the resource.",
 				Type:                types.StringType,
+				Validators: []tfsdk.AttributeValidator{
+					roleNameValidator(),
+				},
 				PlanModifiers: []tfsdk.AttributePlanModifier{
 					tfsdk.RequiresReplace(),
 				},
@@ -56,11 +72,11 @@ One of:
 * MODIFY
 * SELECT`,
 				Type: types.StringType,
-				PlanModifiers: []tfsdk.AttributePlanModifier{
-					tfsdk.RequiresReplace(),
-				},
 			},
 		},
+		Blocks: map[string]tfsdk.Block{
+			"timeouts": timeoutsBlock(),
+		},
 	}, nil
 }
 
@@ -75,15 +91,18 @@ func (t keyspaceGrantResourceType) NewResource(ctx context.Context, in tfsdk.Pro
 type keyspaceGrantResourceData struct {
 	Keyspace   types.String `tfsdk:"keyspace"`
 	Grantee    types.String `tfsdk:"grantee"`
+	Id         types.String `tfsdk:"id"`
 	Permission types.String `tfsdk:"permission"`
+
+	Timeouts []timeoutsData `tfsdk:"timeouts"`
 }
 
 func (t *keyspaceGrantResourceData) resource() qb.CQL {
-	return qb.CQL(fmt.Sprintf("KEYSPACE %s", qb.QName(t.Keyspace.Value)))
+	return qb.CQL(fmt.Sprintf("KEYSPACE %s", cqlName(t.Keyspace.Value)))
 }
 
 func (t *keyspaceGrantResourceData) listResource() string {
-	return fmt.Sprintf("<keyspace %s>", strings.ToLower(t.Keyspace.Value))
+	return fmt.Sprintf("<keyspace %s>", qb.NormalizeName(t.Keyspace.Value))
 }
 
 func (t *keyspaceGrantResourceData) permission() qb.CQL {
@@ -94,12 +113,25 @@ func (t *keyspaceGrantResourceData) grantee() string {
 	return t.Grantee.Value
 }
 
+func (t *keyspaceGrantResourceData) target() (keyspace, table string) {
+	return qb.NormalizeName(t.Keyspace.Value), ""
+}
+
+func (t *keyspaceGrantResourceData) timeouts() []timeoutsData {
+	return t.Timeouts
+}
+
+func (t *keyspaceGrantResourceData) setId() {
+	t.Id = types.String{Value: fmt.Sprintf("%s/%s/%s", t.Grantee.Value, grantIdName(t.Keyspace.Value),
+		strings.ToUpper(t.Permission.Value))}
+}
+
 func (t *keyspaceGrantResourceData) validate() (diags diag.Diagnostics) {
-	if t.Keyspace.IsNull() || t.Keyspace.IsUnknown() || t.Keyspace.Value == "" {
+	if t.Keyspace.IsNull() || t.Keyspace.IsUnknown() {
 		diags.AddAttributeError(path.Root("keyspace"), "Keyspace missing",
 			"Keyspace of the table must be specified.")
 	}
-	if t.Grantee.IsNull() || t.Grantee.IsUnknown() || t.Grantee.Value == "" {
+	if t.Grantee.IsNull() || t.Grantee.IsUnknown() {
 		diags.AddAttributeError(path.Root("grantee"), "Grantee missing",
 			"Grantee must be specified.")
 	}
@@ -107,16 +139,7 @@ func (t *keyspaceGrantResourceData) validate() (diags diag.Diagnostics) {
 		diags.AddAttributeError(path.Root("permission"), "Permission missing",
 			"Permission must be specified.")
 	} else {
-		perm := strings.ToUpper(t.Permission.Value)
-		permNames := make([]string, 0, len(keyspacePermissions))
-		for k := range keyspacePermissions {
-			permNames = append(permNames, k)
-		}
-		sort.Strings(permNames)
-		if _, ok := keyspacePermissions[perm]; !ok {
-			diags.AddAttributeError(path.Root("permission"), "Unsupported permission",
-				fmt.Sprintf("Permission must be one of %s", permNames))
-		}
+		diags.Append(validatePermission(path.Root("permission"), t.Permission, keyspacePermissions, "a keyspace")...)
 	}
 
 	return
@@ -126,6 +149,12 @@ type keyspaceGrantResource struct {
 	provider provider
 }
 
+func (r keyspaceGrantResource) UpgradeState(ctx context.Context) map[int64]tfsdk.ResourceStateUpgrader {
+	return map[int64]tfsdk.ResourceStateUpgrader{
+		0: upgradeStateAddTimeouts(),
+	}
+}
+
 var keyspacePermissions = map[string]struct{}{
 	"CREATE":    {},
 	"ALTER":     {},
@@ -133,7 +162,14 @@ var keyspacePermissions = map[string]struct{}{
 	"SELECT":    {},
 	"MODIFY":    {},
 	"AUTHORIZE": {},
-	"DESCRIBE":  {},
+}
+
+func (r keyspaceGrantResource) ValidateConfig(ctx context.Context, req tfsdk.ValidateResourceConfigRequest, resp *tfsdk.ValidateResourceConfigResponse) {
+	var data keyspaceGrantResourceData
+
+	diags := req.Config.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(validatePermission(path.Root("permission"), data.Permission, keyspacePermissions, "a keyspace")...)
 }
 
 func (r keyspaceGrantResource) Create(ctx context.Context, req tfsdk.CreateResourceRequest, resp *tfsdk.CreateResourceResponse) {
@@ -147,7 +183,8 @@ func (r keyspaceGrantResource) Read(ctx context.Context, req tfsdk.ReadResourceR
 }
 
 func (r keyspaceGrantResource) Update(ctx context.Context, req tfsdk.UpdateResourceRequest, resp *tfsdk.UpdateResourceResponse) {
-	resp.Diagnostics.AddError("Update not supported", "Grant resource does not support update, only recreate")
+	var plan, state keyspaceGrantResourceData
+	r.provider.updateGrant(ctx, req, resp, &plan, &state)
 }
 
 func (r keyspaceGrantResource) Delete(ctx context.Context, req tfsdk.DeleteResourceRequest, resp *tfsdk.DeleteResourceResponse) {
@@ -156,5 +193,5 @@ func (r keyspaceGrantResource) Delete(ctx context.Context, req tfsdk.DeleteResou
 }
 
 func (r keyspaceGrantResource) ImportState(ctx context.Context, req tfsdk.ImportResourceStateRequest, resp *tfsdk.ImportResourceStateResponse) {
-	tfsdk.ResourceImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	importGrant(ctx, req, resp, "grantee", "keyspace", "permission")
 }
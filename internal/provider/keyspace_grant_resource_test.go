@@ -0,0 +1,179 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestKeyspaceGrantResourceLifecycle(t *testing.T) {
+	cluster := newFakeCluster()
+	cluster.addRole("app")
+	cluster.keyspaces["shop"] = struct{}{}
+	r := newTestResource(t, keyspaceGrantResourceType{}, cluster.provider())
+
+	createResp := r.create(&keyspaceGrantResourceData{
+		Keyspace:   types.String{Value: "Shop"},
+		Grantee:    types.String{Value: "app"},
+		Id:         types.String{Unknown: true},
+		Permission: types.String{Value: "select"},
+	})
+	require.False(t, createResp.Diagnostics.HasError(), "%v", createResp.Diagnostics)
+	assert.True(t, cluster.hasPermission("app", "<keyspace shop>", "SELECT"))
+
+	readResp := r.read(createResp.State)
+	require.False(t, readResp.Diagnostics.HasError(), "%v", readResp.Diagnostics)
+
+	var read keyspaceGrantResourceData
+	getTestState(t, readResp.State, &read)
+	assert.Equal(t, "app/shop/SELECT", read.Id.Value)
+
+	// A grant revoked outside of Terraform is removed from the state by the next operation,
+	// which starts with an empty permission cache.
+	cluster.revoke("app", "<keyspace shop>", "SELECT")
+	r = newTestResource(t, keyspaceGrantResourceType{}, cluster.provider())
+	revokedResp := r.read(createResp.State)
+	require.False(t, revokedResp.Diagnostics.HasError(), "%v", revokedResp.Diagnostics)
+	assert.True(t, revokedResp.State.Raw.IsNull())
+
+	// So is a grant of a dropped role.
+	_, err := cluster.Execute(context.Background(), 0, `DROP ROLE "app"`, nil)
+	require.NoError(t, err)
+	r = newTestResource(t, keyspaceGrantResourceType{}, cluster.provider())
+	droppedResp := r.read(createResp.State)
+	require.False(t, droppedResp.Diagnostics.HasError(), "%v", droppedResp.Diagnostics)
+	assert.True(t, droppedResp.State.Raw.IsNull())
+}
+
+func TestKeyspaceGrantResourceQuotedKeyspace(t *testing.T) {
+	cluster := newFakeCluster()
+	cluster.addRole("app")
+	cluster.keyspaces["Shop"] = struct{}{}
+	r := newTestResource(t, keyspaceGrantResourceType{}, cluster.provider())
+
+	createResp := r.create(&keyspaceGrantResourceData{
+		Keyspace:   types.String{Value: `"Shop"`},
+		Grantee:    types.String{Value: "app"},
+		Id:         types.String{Unknown: true},
+		Permission: types.String{Value: "SELECT"},
+	})
+	require.False(t, createResp.Diagnostics.HasError(), "%v", createResp.Diagnostics)
+	assert.True(t, cluster.hasPermission("app", "<keyspace Shop>", "SELECT"))
+	assert.False(t, cluster.hasPermission("app", "<keyspace shop>", "SELECT"))
+
+	readResp := r.read(createResp.State)
+	require.False(t, readResp.Diagnostics.HasError(), "%v", readResp.Diagnostics)
+	assert.False(t, readResp.State.Raw.IsNull())
+}
+
+func TestKeyspaceGrantResourceReadDroppedKeyspace(t *testing.T) {
+	cluster := newFakeCluster()
+	cluster.addRole("app")
+	_, err := cluster.Execute(context.Background(), 0, `GRANT SELECT ON KEYSPACE "shop" TO "app"`, nil)
+	require.NoError(t, err)
+	r := newTestResource(t, keyspaceGrantResourceType{}, cluster.provider())
+
+	// The keyspace was dropped, even though the server still lists the permission.
+	resp := r.read(r.state(&keyspaceGrantResourceData{
+		Keyspace:   types.String{Value: "shop"},
+		Grantee:    types.String{Value: "app"},
+		Id:         types.String{Value: "app/shop/SELECT"},
+		Permission: types.String{Value: "SELECT"},
+	}))
+	require.False(t, resp.Diagnostics.HasError(), "%v", resp.Diagnostics)
+	assert.True(t, resp.State.Raw.IsNull())
+	assert.Equal(t, []string{
+		"SELECT keyspace_name FROM system_schema.keyspaces",
+		"SELECT keyspace_name, table_name FROM system_schema.tables",
+	}, cluster.executed[1:])
+}
+
+func TestKeyspaceGrantResourceReadListsSchemaOnce(t *testing.T) {
+	cluster := newFakeCluster()
+	cluster.addRole("app")
+	keyspaces := []string{"orders", "shop", "users"}
+	for _, keyspace := range keyspaces {
+		cluster.keyspaces[keyspace] = struct{}{}
+		_, err := cluster.Execute(context.Background(), 0, `GRANT SELECT ON KEYSPACE "`+keyspace+`" TO "app"`, nil)
+		require.NoError(t, err)
+	}
+	r := newTestResource(t, keyspaceGrantResourceType{}, cluster.provider())
+
+	executed := len(cluster.executed)
+	for _, keyspace := range keyspaces {
+		resp := r.read(r.state(&keyspaceGrantResourceData{
+			Keyspace:   types.String{Value: keyspace},
+			Grantee:    types.String{Value: "app"},
+			Id:         types.String{Value: "app/" + keyspace + "/SELECT"},
+			Permission: types.String{Value: "SELECT"},
+		}))
+		require.False(t, resp.Diagnostics.HasError(), "%v", resp.Diagnostics)
+		assert.False(t, resp.State.Raw.IsNull(), keyspace)
+	}
+	assert.Equal(t, []string{
+		"SELECT keyspace_name FROM system_schema.keyspaces",
+		"SELECT keyspace_name, table_name FROM system_schema.tables",
+		`LIST ALL PERMISSIONS OF "app" NORECURSIVE`,
+	}, cluster.executed[executed:])
+}
+
+func TestRequiresReplaceIfNameChanged(t *testing.T) {
+	ctx := context.Background()
+	modifier := requiresReplaceIfNameChanged()
+	schema := newTestResource(t, keyspaceGrantResourceType{}, provider{}).schema
+
+	tests := []struct {
+		state, config string
+		replace       bool
+	}{
+		{"shop", "Shop", false},
+		{"shop", `"shop"`, false},
+		{"shop", `"Shop"`, true},
+		{`"Shop"`, "shop", true},
+		{"shop", "store", true},
+	}
+	for _, tt := range tests {
+		data := func(keyspace string) *keyspaceGrantResourceData {
+			return &keyspaceGrantResourceData{
+				Keyspace:   types.String{Value: keyspace},
+				Grantee:    types.String{Value: "app"},
+				Id:         types.String{Value: "app/" + keyspace + "/SELECT"},
+				Permission: types.String{Value: "SELECT"},
+			}
+		}
+		state := newTestState(t, schema, data(tt.state))
+		config := newTestState(t, schema, data(tt.config))
+		resp := tfsdk.ModifyAttributePlanResponse{AttributePlan: types.String{Value: tt.config}}
+		modifier.Modify(ctx, tfsdk.ModifyAttributePlanRequest{
+			AttributePath:   path.Root("keyspace"),
+			AttributeState:  types.String{Value: tt.state},
+			AttributeConfig: types.String{Value: tt.config},
+			AttributePlan:   types.String{Value: tt.config},
+			State:           state,
+			Plan:            tfsdk.Plan{Schema: schema, Raw: config.Raw},
+			Config:          tfsdk.Config{Schema: schema, Raw: config.Raw},
+		}, &resp)
+		require.False(t, resp.Diagnostics.HasError(), "%v", resp.Diagnostics)
+		assert.Equal(t, tt.replace, resp.RequiresReplace, "%s -> %s", tt.state, tt.config)
+	}
+}
+
+func TestKeyspaceGrantResourceCreateMissingRole(t *testing.T) {
+	cluster := newFakeCluster()
+	r := newTestResource(t, keyspaceGrantResourceType{}, cluster.provider())
+
+	resp := r.create(&keyspaceGrantResourceData{
+		Keyspace:   types.String{Value: "shop"},
+		Grantee:    types.String{Value: "nobody"},
+		Id:         types.String{Unknown: true},
+		Permission: types.String{Value: "MODIFY"},
+	})
+	require.True(t, resp.Diagnostics.HasError())
+	assert.Contains(t, resp.Diagnostics[0].Detail(), "doesn't exist")
+	assert.True(t, resp.State.Raw.IsNull())
+}
@@ -0,0 +1,73 @@
+package provider
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/scylladb/scylla-go-driver/transport"
+
+	"github.com/kiwicom/terraform-provider-scylla/internal/scan"
+)
+
+// appliedColumn is the column of results of lightweight transactions telling whether the statement was applied.
+const appliedColumn = "[applied]"
+
+// lwtOutcome is the result of a lightweight transaction.
+type lwtOutcome struct {
+	applied bool
+
+	// current holds the current values of the row that made the condition fail, by column name.
+	// Null values are omitted. It is empty if the statement was applied or the row does not exist.
+	current map[string]string
+}
+
+// parseLWTResult reads the result of a lightweight transaction.
+func parseLWTResult(result transport.QueryResult) (lwtOutcome, error) {
+	if len(result.Rows) == 0 {
+		return lwtOutcome{}, fmt.Errorf("lightweight transaction returned no rows")
+	}
+	var applied struct {
+		Applied bool `cql:"[applied]"`
+	}
+	if err := scan.Row(result, 0, &applied); err != nil {
+		return lwtOutcome{}, err
+	}
+
+	row := result.Rows[0]
+	outcome := lwtOutcome{applied: applied.Applied, current: make(map[string]string)}
+	for i, spec := range result.ColSpec {
+		if spec.Name == appliedColumn || row[i].Value == nil {
+			continue
+		}
+		value, err := cqlValueString(row[i])
+		if err != nil {
+			return lwtOutcome{}, fmt.Errorf("read %s: %w", spec.Name, err)
+		}
+		outcome.current[spec.Name] = value
+	}
+	return outcome, nil
+}
+
+// lwtConflictDiagnostic reports a lightweight transaction that was not applied because the row was changed
+// by another writer, listing the current values of the row.
+func lwtConflictDiagnostic(stmt string, outcome lwtOutcome) diag.Diagnostic {
+	detail := fmt.Sprintf("The statement was not applied because its condition does not hold:\n%s\n", stmt)
+	if len(outcome.current) == 0 {
+		detail += "\nThe row does not exist."
+	} else {
+		names := make([]string, 0, len(outcome.current))
+		for name := range outcome.current {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		var sb strings.Builder
+		for _, name := range names {
+			fmt.Fprintf(&sb, "\n  %s = %s", name, outcome.current[name])
+		}
+		detail += "\nThe row was changed outside of Terraform, its current values are:" + sb.String()
+	}
+	detail += "\n\nRefresh the state to see the current values before applying again."
+	return diag.NewErrorDiagnostic("Conflicting write", detail)
+}
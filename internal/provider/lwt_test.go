@@ -0,0 +1,41 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/scylladb/scylla-go-driver/frame"
+	"github.com/scylladb/scylla-go-driver/transport"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseLWTResult(t *testing.T) {
+	applied, err := parseLWTResult(transport.QueryResult{
+		ColSpec: []frame.ColumnSpec{{Name: "[applied]"}},
+		Rows:    []frame.Row{{frame.CqlFromBoolean(true)}},
+	})
+	require.NoError(t, err)
+	assert.True(t, applied.applied)
+	assert.Empty(t, applied.current)
+
+	conflict, err := parseLWTResult(transport.QueryResult{
+		ColSpec: []frame.ColumnSpec{{Name: "[applied]"}, {Name: "name"}, {Name: "value"}, {Name: "owner"}},
+		Rows: []frame.Row{{
+			frame.CqlFromBoolean(false), fakeText("limit"), frame.CqlFromInt32(20),
+			frame.CqlValue{Type: &frame.Option{ID: frame.VarcharID}},
+		}},
+	})
+	require.NoError(t, err)
+	assert.False(t, conflict.applied)
+	assert.Equal(t, map[string]string{"name": "limit", "value": "20"}, conflict.current)
+
+	d := lwtConflictDiagnostic("UPDATE ks.settings SET value = 10 WHERE name = 'limit' IF value = 5", conflict)
+	assert.Equal(t, "Conflicting write", d.Summary())
+	assert.Contains(t, d.Detail(), "its current values are:\n  name = limit\n  value = 20")
+
+	d = lwtConflictDiagnostic("UPDATE ks.settings SET value = 10 WHERE name = 'limit' IF value = 5", lwtOutcome{})
+	assert.Contains(t, d.Detail(), "The row does not exist.")
+
+	_, err = parseLWTResult(transport.QueryResult{ColSpec: []frame.ColumnSpec{{Name: "name"}}, Rows: []frame.Row{{fakeText("x")}}})
+	assert.Error(t, err)
+}
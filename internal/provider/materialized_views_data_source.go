@@ -0,0 +1,204 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/kiwicom/terraform-provider-scylla/internal/qb"
+	"github.com/kiwicom/terraform-provider-scylla/internal/scan"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces
+var _ tfsdk.DataSourceType = materializedViewsDataSourceType{}
+var _ tfsdk.DataSource = materializedViewsDataSource{}
+
+type materializedViewsDataSourceType struct{}
+
+func (t materializedViewsDataSourceType) GetSchema(ctx context.Context) (tfsdk.Schema, diag.Diagnostics) {
+	return tfsdk.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Lists materialized views of a table",
+
+		Attributes: map[string]tfsdk.Attribute{
+			"keyspace": {
+				MarkdownDescription: "Name of the keyspace where the table resides",
+				Required:            true,
+				Type:                types.StringType,
+			},
+			"table": {
+				MarkdownDescription: "Name of the base table",
+				Required:            true,
+				Type:                types.StringType,
+			},
+			"id": {
+				MarkdownDescription: "Identifier of the data source",
+				Computed:            true,
+				Type:                types.StringType,
+			},
+			"views": {
+				MarkdownDescription: "Materialized views of the table sorted by name.",
+				Computed:            true,
+				Attributes: tfsdk.ListNestedAttributes(map[string]tfsdk.Attribute{
+					"name": {
+						MarkdownDescription: "Name of the view",
+						Computed:            true,
+						Type:                types.StringType,
+					},
+					"include_all_columns": {
+						MarkdownDescription: "Indicates whether the view was created with `SELECT *`.",
+						Computed:            true,
+						Type:                types.BoolType,
+					},
+					"columns": {
+						MarkdownDescription: "Names of the columns included in the view.",
+						Computed:            true,
+						Type:                types.ListType{ElemType: types.StringType},
+					},
+					"where_clause": {
+						MarkdownDescription: "The WHERE clause of the view.",
+						Computed:            true,
+						Type:                types.StringType,
+					},
+				}),
+			},
+		},
+	}, nil
+}
+
+func (t materializedViewsDataSourceType) NewDataSource(ctx context.Context, in tfsdk.Provider) (tfsdk.DataSource, diag.Diagnostics) {
+	provider, diags := convertProviderType(in)
+
+	return materializedViewsDataSource{
+		provider: provider,
+	}, diags
+}
+
+type materializedViewsDataSourceData struct {
+	Keyspace types.String                          `tfsdk:"keyspace"`
+	Table    types.String                          `tfsdk:"table"`
+	Id       types.String                          `tfsdk:"id"`
+	Views    []materializedViewsDataSourceViewData `tfsdk:"views"`
+}
+
+type materializedViewsDataSourceViewData struct {
+	Name              types.String `tfsdk:"name"`
+	IncludeAllColumns types.Bool   `tfsdk:"include_all_columns"`
+	Columns           []string     `tfsdk:"columns"`
+	WhereClause       types.String `tfsdk:"where_clause"`
+}
+
+type materializedViewsDataSource struct {
+	provider provider
+}
+
+func (d materializedViewsDataSource) Read(ctx context.Context, req tfsdk.ReadDataSourceRequest, resp *tfsdk.ReadDataSourceResponse) {
+	var data materializedViewsDataSourceData
+
+	diags := req.Config.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	values, err := bind(data.Keyspace.Value)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("keyspace"), "Cannot convert keyspace name", err.Error())
+		return
+	}
+
+	result, err := d.provider.execute(ctx,
+		"SELECT view_name, base_table_name, include_all_columns, where_clause FROM system_schema.views WHERE keyspace_name = ?",
+		values)
+	if err != nil {
+		resp.Diagnostics.Append(cqlErrorDiagnostic("Query error", "Unable to read views.", err))
+		return
+	}
+
+	var rows []struct {
+		Name              string `cql:"view_name"`
+		BaseTable         string `cql:"base_table_name"`
+		IncludeAllColumns bool   `cql:"include_all_columns"`
+		WhereClause       string `cql:"where_clause"`
+	}
+	if err := scan.Rows(result, &rows); err != nil {
+		resp.Diagnostics.AddError("Query result error", fmt.Sprintf("Unable to read views: %s", err))
+		return
+	}
+
+	data.Views = make([]materializedViewsDataSourceViewData, 0)
+	for _, row := range rows {
+		if row.BaseTable != data.Table.Value {
+			continue
+		}
+		name, includeAll, whereClause := row.Name, row.IncludeAllColumns, row.WhereClause
+
+		columns, diags := d.readColumns(ctx, data.Keyspace.Value, name)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		data.Views = append(data.Views, materializedViewsDataSourceViewData{
+			Name:              types.String{Value: name},
+			IncludeAllColumns: types.Bool{Value: includeAll},
+			Columns:           columns,
+			WhereClause:       types.String{Value: whereClause},
+		})
+	}
+
+	// The order of rows of system_schema.views is not documented, the views are sorted so that it does not matter.
+	sort.Slice(data.Views, func(i, j int) bool {
+		return data.Views[i].Name.Value < data.Views[j].Name.Value
+	})
+
+	data.Id = types.String{Value: fmt.Sprintf("%s.%s", data.Keyspace.Value, data.Table.Value)}
+
+	diags = resp.State.Set(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (d materializedViewsDataSource) readColumns(ctx context.Context, keyspace, view string) ([]string, diag.Diagnostics) {
+	var stmt qb.Builder
+	stmt.Append("SELECT column_name FROM system_schema.columns WHERE keyspace_name = ")
+	stmt.AppendParam(keyspace)
+	stmt.Append(" AND table_name = ")
+	stmt.AppendParam(view)
+	query, params := stmt.Build()
+
+	values, err := bind(params...)
+	if err != nil {
+		return nil, diag.Diagnostics{
+			diag.NewErrorDiagnostic("Cannot convert view name", err.Error()),
+		}
+	}
+
+	result, err := d.provider.execute(ctx, query, values)
+	if err != nil {
+		return nil, diag.Diagnostics{
+			cqlErrorDiagnostic("Query error", fmt.Sprintf("Unable to read columns of view %q.", view), err),
+		}
+	}
+
+	var rows []struct {
+		Name string `cql:"column_name"`
+	}
+	if err := scan.Rows(result, &rows); err != nil {
+		return nil, diag.Diagnostics{
+			diag.NewErrorDiagnostic("Query result error",
+				fmt.Sprintf("Unable to read columns of view %q: %s", view, err)),
+		}
+	}
+
+	columns := make([]string, 0, len(rows))
+	for _, row := range rows {
+		columns = append(columns, row.Name)
+	}
+	return columns, nil
+}
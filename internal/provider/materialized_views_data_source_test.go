@@ -0,0 +1,52 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMaterializedViewsDataSourceRead(t *testing.T) {
+	cluster := newFakeCluster()
+	cluster.views["shop.orders_by_customer"] = &fakeView{
+		baseTable:   "orders",
+		whereClause: "customer_id IS NOT NULL AND id IS NOT NULL",
+		columns:     []string{"customer_id", "id"},
+	}
+	cluster.views["shop.orders_by_date"] = &fakeView{
+		baseTable:   "orders",
+		includeAll:  true,
+		whereClause: "date IS NOT NULL AND id IS NOT NULL",
+		columns:     []string{"date", "id", "total"},
+	}
+	cluster.views["shop.users_by_email"] = &fakeView{baseTable: "users", whereClause: "email IS NOT NULL"}
+	cluster.views["other.orders_by_total"] = &fakeView{baseTable: "orders", whereClause: "total IS NOT NULL"}
+
+	ds := newTestDataSource(t, materializedViewsDataSourceType{}, cluster.provider())
+	resp := ds.read(&materializedViewsDataSourceData{
+		Keyspace: types.String{Value: "shop"},
+		Table:    types.String{Value: "orders"},
+		Id:       types.String{Null: true},
+	})
+	require.False(t, resp.Diagnostics.HasError(), "%v", resp.Diagnostics)
+
+	var data materializedViewsDataSourceData
+	getTestState(t, resp.State, &data)
+	assert.Equal(t, "shop.orders", data.Id.Value)
+	assert.Equal(t, []materializedViewsDataSourceViewData{
+		{
+			Name:              types.String{Value: "orders_by_customer"},
+			IncludeAllColumns: types.Bool{Value: false},
+			Columns:           []string{"customer_id", "id"},
+			WhereClause:       types.String{Value: "customer_id IS NOT NULL AND id IS NOT NULL"},
+		},
+		{
+			Name:              types.String{Value: "orders_by_date"},
+			IncludeAllColumns: types.Bool{Value: true},
+			Columns:           []string{"date", "id", "total"},
+			WhereClause:       types.String{Value: "date IS NOT NULL AND id IS NOT NULL"},
+		},
+	}, data.Views)
+}
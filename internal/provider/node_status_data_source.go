@@ -0,0 +1,138 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces
+var _ tfsdk.DataSourceType = nodeStatusDataSourceType{}
+var _ tfsdk.DataSource = nodeStatusDataSource{}
+
+type nodeStatusDataSourceType struct{}
+
+func (t nodeStatusDataSourceType) GetSchema(ctx context.Context) (tfsdk.Schema, diag.Diagnostics) {
+	return tfsdk.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Reports the state of the cluster nodes as seen by gossip, using the Scylla REST API " +
+			"configured by `rest_api_endpoint` of the provider. " +
+			"It can be used to gate dangerous changes on the health of the cluster, for example with a precondition " +
+			"on `all_up`.",
+
+		Attributes: map[string]tfsdk.Attribute{
+			"id": {
+				MarkdownDescription: "Endpoint of the REST API the state was read from",
+				Computed:            true,
+				Type:                types.StringType,
+			},
+			"uptime_ms": {
+				MarkdownDescription: "Time in milliseconds since the node the REST API is queried on started.",
+				Computed:            true,
+				Type:                types.Int64Type,
+			},
+			"all_up": {
+				MarkdownDescription: "Whether all nodes are `UP`.",
+				Computed:            true,
+				Type:                types.BoolType,
+			},
+			"nodes": {
+				MarkdownDescription: "Nodes of the cluster sorted by address.",
+				Computed:            true,
+				Attributes: tfsdk.ListNestedAttributes(map[string]tfsdk.Attribute{
+					"address": {
+						MarkdownDescription: "Address of the node.",
+						Computed:            true,
+						Type:                types.StringType,
+					},
+					"status": {
+						MarkdownDescription: "Gossip state of the node, `UP` or `DOWN`. " +
+							"`UNKNOWN` for nodes that own tokens but have no gossip state.",
+						Computed: true,
+						Type:     types.StringType,
+					},
+					"load": {
+						MarkdownDescription: "Size of the data on the node in bytes.",
+						Computed:            true,
+						Type:                types.Int64Type,
+					},
+				}),
+			},
+		},
+	}, nil
+}
+
+func (t nodeStatusDataSourceType) NewDataSource(ctx context.Context, in tfsdk.Provider) (tfsdk.DataSource, diag.Diagnostics) {
+	provider, diags := convertProviderType(in)
+
+	return nodeStatusDataSource{
+		provider: provider,
+	}, diags
+}
+
+type nodeStatusDataSourceData struct {
+	Id       types.String                   `tfsdk:"id"`
+	UptimeMs types.Int64                    `tfsdk:"uptime_ms"`
+	AllUp    types.Bool                     `tfsdk:"all_up"`
+	Nodes    []nodeStatusDataSourceNodeData `tfsdk:"nodes"`
+}
+
+type nodeStatusDataSourceNodeData struct {
+	Address types.String `tfsdk:"address"`
+	Status  types.String `tfsdk:"status"`
+	Load    types.Int64  `tfsdk:"load"`
+}
+
+type nodeStatusDataSource struct {
+	provider provider
+}
+
+func (d nodeStatusDataSource) Read(ctx context.Context, req tfsdk.ReadDataSourceRequest, resp *tfsdk.ReadDataSourceResponse) {
+	var data nodeStatusDataSourceData
+
+	diags := req.Config.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	api := d.provider.restAPI
+	if api == nil {
+		resp.Diagnostics.AddError("REST API not configured",
+			"Set rest_api_endpoint of the provider, or configure hosts to use the REST API on the first of them.")
+		return
+	}
+
+	nodes, err := api.nodeStatuses(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError("REST API error", fmt.Sprintf("Unable to read the state of nodes from %s:\n%s", api.endpoint, err))
+		return
+	}
+	uptime, err := api.uptime(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError("REST API error", fmt.Sprintf("Unable to read uptime from %s:\n%s", api.endpoint, err))
+		return
+	}
+
+	data.Id = types.String{Value: api.endpoint}
+	data.UptimeMs = types.Int64{Value: uptime.Milliseconds()}
+	data.AllUp = types.Bool{Value: true}
+	data.Nodes = make([]nodeStatusDataSourceNodeData, 0, len(nodes))
+	for _, n := range nodes {
+		if n.status != "UP" {
+			data.AllUp = types.Bool{Value: false}
+		}
+		data.Nodes = append(data.Nodes, nodeStatusDataSourceNodeData{
+			Address: types.String{Value: n.address},
+			Status:  types.String{Value: n.status},
+			Load:    types.Int64{Value: n.load},
+		})
+	}
+
+	diags = resp.State.Set(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+}
@@ -0,0 +1,78 @@
+package provider
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNodeStatusDataSourceRead(t *testing.T) {
+	responses := map[string]string{
+		"/gossiper/endpoint/live/": `["10.0.0.2","10.0.0.1"]`,
+		"/gossiper/endpoint/down/": `["10.0.0.3"]`,
+		"/storage_service/load_map": `[{"key":"10.0.0.1","value":1.5E9},{"key":"10.0.0.2","value":2048},` +
+			`{"key":"10.0.0.3","value":1024},{"key":"10.0.0.4","value":0}]`,
+		"/system/uptime_ms": `3600000`,
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, ok := responses[r.URL.Path]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(body))
+	}))
+	defer server.Close()
+
+	api, err := newRESTClient(server.URL+"/", nil)
+	require.NoError(t, err)
+	ds := newTestDataSource(t, nodeStatusDataSourceType{}, provider{restAPI: api, configured: true})
+
+	resp := ds.read(&nodeStatusDataSourceData{
+		Id: types.String{Null: true}, UptimeMs: types.Int64{Null: true}, AllUp: types.Bool{Null: true},
+	})
+	require.False(t, resp.Diagnostics.HasError(), "%v", resp.Diagnostics)
+
+	var data nodeStatusDataSourceData
+	getTestState(t, resp.State, &data)
+	assert.Equal(t, server.URL, data.Id.Value)
+	assert.Equal(t, int64(3600000), data.UptimeMs.Value)
+	assert.False(t, data.AllUp.Value)
+	assert.Equal(t, []nodeStatusDataSourceNodeData{
+		{Address: types.String{Value: "10.0.0.1"}, Status: types.String{Value: "UP"}, Load: types.Int64{Value: 1500000000}},
+		{Address: types.String{Value: "10.0.0.2"}, Status: types.String{Value: "UP"}, Load: types.Int64{Value: 2048}},
+		{Address: types.String{Value: "10.0.0.3"}, Status: types.String{Value: "DOWN"}, Load: types.Int64{Value: 1024}},
+		{Address: types.String{Value: "10.0.0.4"}, Status: types.String{Value: "UNKNOWN"}, Load: types.Int64{Value: 0}},
+	}, data.Nodes)
+}
+
+func TestNodeStatusDataSourceReadError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "boom", http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	api, err := newRESTClient(server.URL, nil)
+	require.NoError(t, err)
+	ds := newTestDataSource(t, nodeStatusDataSourceType{}, provider{restAPI: api, configured: true})
+
+	resp := ds.read(&nodeStatusDataSourceData{
+		Id: types.String{Null: true}, UptimeMs: types.Int64{Null: true}, AllUp: types.Bool{Null: true},
+	})
+	require.True(t, resp.Diagnostics.HasError())
+	assert.Contains(t, resp.Diagnostics[0].Detail(), "500 Internal Server Error: boom")
+}
+
+func TestDefaultRESTEndpoint(t *testing.T) {
+	assert.Equal(t, "http://10.0.0.1:10000", defaultRESTEndpoint("10.0.0.1:9042"))
+	assert.Equal(t, "http://[::1]:10000", defaultRESTEndpoint("[::1]:9042"))
+	assert.Equal(t, "http://scylla:10000", defaultRESTEndpoint("scylla"))
+
+	_, err := newRESTClient("10.0.0.1:10000", nil)
+	assert.Error(t, err)
+}
@@ -0,0 +1,257 @@
+package provider
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/kiwicom/terraform-provider-scylla/internal/qb"
+	"github.com/kiwicom/terraform-provider-scylla/internal/scan"
+)
+
+// permissionCacheTTL limits how long listed permissions of a role are reused.
+// A provider process serves a single Terraform operation, the TTL only bounds staleness within long ones.
+const permissionCacheTTL = 30 * time.Second
+
+// permissionRow is a permission as listed by LIST PERMISSIONS.
+type permissionRow struct {
+	Role       string `cql:"role"`
+	Resource   string `cql:"resource"`
+	Permission string `cql:"permission"`
+}
+
+// permissionCache holds the permissions granted directly to roles, so that refreshing many grants
+// of a role needs a single LIST ALL PERMISSIONS statement. The zero value is ready to use.
+//
+// With prefetching, permissions of all roles are listed by a single statement and shared by all roles,
+// see getFromSnapshot.
+type permissionCache struct {
+	mu      sync.Mutex
+	entries map[string]*permissionCacheEntry
+
+	// snapshot holds the permissions of all roles, if they were prefetched.
+	snapshot *permissionSnapshot
+
+	// snapshotDisabled is set when the permissions of all roles cannot be listed by the connected role.
+	snapshotDisabled bool
+
+	// stale holds the roles whose permissions changed since the snapshot was fetched.
+	stale map[string]struct{}
+
+	// now returns the current time, it is replaced in tests.
+	now func() time.Time
+}
+
+type permissionSnapshot struct {
+	// done is closed once the permissions are fetched.
+	done chan struct{}
+
+	fetched time.Time
+	roles   map[string][]permissionRow
+	err     error
+}
+
+type permissionCacheEntry struct {
+	// done is closed once the permissions are fetched.
+	done chan struct{}
+
+	fetched time.Time
+	rows    []permissionRow
+	err     error
+}
+
+// get returns the cached permissions of the role, calling fetch if they are not cached or expired.
+// Concurrent calls for the same role share a single fetch. Errors are not cached.
+func (c *permissionCache) get(ctx context.Context, role string,
+	fetch func(ctx context.Context) ([]permissionRow, error)) ([]permissionRow, error) {
+	if c == nil {
+		return fetch(ctx)
+	}
+
+	c.mu.Lock()
+	if c.entries == nil {
+		c.entries = make(map[string]*permissionCacheEntry)
+	}
+	e, ok := c.entries[role]
+	if ok {
+		select {
+		case <-e.done:
+			if c.clock().Sub(e.fetched) > permissionCacheTTL {
+				ok = false
+			}
+		default:
+			// Fetch in progress.
+		}
+	}
+	if !ok {
+		e = &permissionCacheEntry{done: make(chan struct{})}
+		c.entries[role] = e
+		c.mu.Unlock()
+
+		e.rows, e.err = fetch(ctx)
+		e.fetched = c.clock()
+		c.mu.Lock()
+		if e.err != nil && c.entries[role] == e {
+			delete(c.entries, role)
+		}
+		c.mu.Unlock()
+		close(e.done)
+		return e.rows, e.err
+	}
+	c.mu.Unlock()
+
+	select {
+	case <-e.done:
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+	if e.err != nil {
+		// The fetch was made for another caller, try on our own.
+		return c.get(ctx, role, fetch)
+	}
+	return e.rows, nil
+}
+
+// invalidate drops the cached permissions of the role, it must be called after they are changed.
+func (c *permissionCache) invalidate(role string) {
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	delete(c.entries, role)
+	if c.snapshot != nil {
+		if c.stale == nil {
+			c.stale = make(map[string]struct{})
+		}
+		c.stale[role] = struct{}{}
+	}
+	c.mu.Unlock()
+}
+
+// getFromSnapshot returns the permissions of the role from the snapshot of permissions of all roles,
+// calling fetchAll if there is no snapshot or it expired. Concurrent calls share a single fetch.
+// The returned bool is false if the snapshot cannot be used for the role, because its permissions changed
+// since the snapshot was fetched or because the permissions of all roles cannot be listed.
+func (c *permissionCache) getFromSnapshot(ctx context.Context, role string,
+	fetchAll func(ctx context.Context) (map[string][]permissionRow, error)) ([]permissionRow, bool, error) {
+	if c == nil {
+		return nil, false, nil
+	}
+
+	c.mu.Lock()
+	if c.snapshotDisabled {
+		c.mu.Unlock()
+		return nil, false, nil
+	}
+	if _, ok := c.stale[role]; ok {
+		c.mu.Unlock()
+		return nil, false, nil
+	}
+	snap := c.snapshot
+	if snap != nil {
+		select {
+		case <-snap.done:
+			if snap.err != nil || c.clock().Sub(snap.fetched) > permissionCacheTTL {
+				snap = nil
+			}
+		default:
+			// Fetch in progress.
+		}
+	}
+	if snap == nil {
+		snap = &permissionSnapshot{done: make(chan struct{})}
+		c.snapshot = snap
+		c.stale = nil
+		c.mu.Unlock()
+
+		snap.roles, snap.err = fetchAll(ctx)
+		snap.fetched = c.clock()
+		if snap.err != nil {
+			c.mu.Lock()
+			if classifyError(snap.err) == errorClassUnauthorized {
+				c.snapshotDisabled = true
+			}
+			if c.snapshot == snap {
+				c.snapshot = nil
+			}
+			c.mu.Unlock()
+		}
+		close(snap.done)
+	} else {
+		c.mu.Unlock()
+		select {
+		case <-snap.done:
+		case <-ctx.Done():
+			return nil, false, ctx.Err()
+		}
+	}
+
+	if snap.err != nil {
+		return nil, false, snap.err
+	}
+	return snap.roles[role], true, nil
+}
+
+func (c *permissionCache) clock() time.Time {
+	if c.now != nil {
+		return c.now()
+	}
+	return time.Now()
+}
+
+// listPermissions returns the permissions granted directly to the role, not the ones inherited from other roles.
+// The result is cached, see permissionCache.
+func (p *provider) listPermissions(ctx context.Context, role string) ([]permissionRow, error) {
+	if p.prefetchPermissions {
+		rows, ok, err := p.permissions.getFromSnapshot(ctx, role, p.listAllPermissions)
+		if err != nil {
+			tflog.Debug(ctx, "Unable to list permissions of all roles, listing permissions of the role",
+				map[string]interface{}{"role": role, "error": err.Error()})
+		}
+		if err == nil && ok {
+			return rows, nil
+		}
+	}
+
+	return p.permissions.get(ctx, role, func(ctx context.Context) ([]permissionRow, error) {
+		var stmt qb.Builder
+		stmt.Appendf("LIST ALL PERMISSIONS OF %s NORECURSIVE", qb.QName(role))
+
+		result, err := p.executeAuthRead(ctx, stmt.String(), nil)
+		if err != nil {
+			return nil, err
+		}
+
+		var rows []permissionRow
+		if err := scan.Rows(result, &rows); err != nil {
+			return nil, err
+		}
+		filtered := rows[:0]
+		for _, row := range rows {
+			if row.Role == role {
+				filtered = append(filtered, row)
+			}
+		}
+		return filtered, nil
+	})
+}
+
+// listAllPermissions returns the permissions granted directly to every role that has some, keyed by role.
+func (p *provider) listAllPermissions(ctx context.Context) (map[string][]permissionRow, error) {
+	result, err := p.executeAuthRead(ctx, "LIST ALL PERMISSIONS", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var rows []permissionRow
+	if err := scan.Rows(result, &rows); err != nil {
+		return nil, err
+	}
+	roles := make(map[string][]permissionRow)
+	for _, row := range rows {
+		roles[row.Role] = append(roles[row.Role], row)
+	}
+	return roles, nil
+}
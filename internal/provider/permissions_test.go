@@ -0,0 +1,161 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPermissionCache(t *testing.T) {
+	ctx := context.Background()
+	now := time.Unix(0, 0)
+	c := permissionCache{now: func() time.Time { return now }}
+
+	fetches := 0
+	fetch := func(ctx context.Context) ([]permissionRow, error) {
+		fetches++
+		return []permissionRow{{Role: "app", Resource: "<keyspace ks>", Permission: "SELECT"}}, nil
+	}
+
+	rows, err := c.get(ctx, "app", fetch)
+	require.NoError(t, err)
+	assert.Len(t, rows, 1)
+	_, err = c.get(ctx, "app", fetch)
+	require.NoError(t, err)
+	assert.Equal(t, 1, fetches)
+
+	c.invalidate("app")
+	_, err = c.get(ctx, "app", fetch)
+	require.NoError(t, err)
+	assert.Equal(t, 2, fetches)
+
+	now = now.Add(permissionCacheTTL + time.Second)
+	_, err = c.get(ctx, "app", fetch)
+	require.NoError(t, err)
+	assert.Equal(t, 3, fetches)
+}
+
+func TestPermissionCache_ErrorNotCached(t *testing.T) {
+	ctx := context.Background()
+	var c permissionCache
+
+	fetches := 0
+	fetch := func(ctx context.Context) ([]permissionRow, error) {
+		fetches++
+		return nil, errors.New("unavailable")
+	}
+	_, err := c.get(ctx, "app", fetch)
+	assert.Error(t, err)
+	_, err = c.get(ctx, "app", fetch)
+	assert.Error(t, err)
+	assert.Equal(t, 2, fetches)
+}
+
+func TestGrantReadUsesPermissionCache(t *testing.T) {
+	ctx := context.Background()
+	cluster := newFakeCluster()
+	cluster.addRole("app")
+	for _, ks := range []string{"one", "two", "three"} {
+		cluster.keyspaces[ks] = struct{}{}
+	}
+	r := newTestResource(t, keyspaceGrantResourceType{}, cluster.provider())
+
+	for _, ks := range []string{"one", "two"} {
+		_, err := cluster.Execute(ctx, 0, `GRANT SELECT ON KEYSPACE "`+ks+`" TO "app"`, nil)
+		require.NoError(t, err)
+	}
+
+	for _, ks := range []string{"one", "two", "three"} {
+		resp := r.read(r.state(&keyspaceGrantResourceData{
+			Keyspace:   types.String{Value: ks},
+			Grantee:    types.String{Value: "app"},
+			Id:         types.String{Value: "app/" + ks + "/SELECT"},
+			Permission: types.String{Value: "SELECT"},
+		}))
+		require.False(t, resp.Diagnostics.HasError(), "%v", resp.Diagnostics)
+		assert.Equal(t, ks == "three", resp.State.Raw.IsNull(), ks)
+	}
+
+	lists := 0
+	for _, stmt := range cluster.executed {
+		if strings.HasPrefix(stmt, "LIST ALL PERMISSIONS") {
+			lists++
+		}
+	}
+	assert.Equal(t, 1, lists)
+}
+
+func TestGrantReadPrefetchesPermissions(t *testing.T) {
+	for _, denyListAll := range []bool{false, true} {
+		ctx := context.Background()
+		cluster := newFakeCluster()
+		cluster.denyListAll = denyListAll
+		cluster.keyspaces["ks"] = struct{}{}
+		p := cluster.provider()
+		p.prefetchPermissions = true
+		r := newTestResource(t, keyspaceGrantResourceType{}, p)
+
+		grantees := []string{"app", "batch", "report"}
+		for _, grantee := range grantees {
+			cluster.addRole(grantee)
+			_, err := cluster.Execute(ctx, 0, `GRANT SELECT ON KEYSPACE "ks" TO "`+grantee+`"`, nil)
+			require.NoError(t, err)
+		}
+
+		for _, grantee := range grantees {
+			resp := r.read(r.state(&keyspaceGrantResourceData{
+				Keyspace:   types.String{Value: "ks"},
+				Grantee:    types.String{Value: grantee},
+				Id:         types.String{Value: grantee + "/ks/SELECT"},
+				Permission: types.String{Value: "SELECT"},
+			}))
+			require.False(t, resp.Diagnostics.HasError(), "%v", resp.Diagnostics)
+			assert.False(t, resp.State.Raw.IsNull(), grantee)
+		}
+
+		listAll, listOf := 0, 0
+		for _, stmt := range cluster.executed {
+			switch {
+			case stmt == "LIST ALL PERMISSIONS":
+				listAll++
+			case strings.HasPrefix(stmt, "LIST ALL PERMISSIONS OF"):
+				listOf++
+			}
+		}
+		assert.Equal(t, 1, listAll, "denyListAll=%v", denyListAll)
+		if denyListAll {
+			assert.Equal(t, len(grantees), listOf)
+		} else {
+			assert.Zero(t, listOf)
+		}
+	}
+}
+
+func TestPermissionCache_SnapshotInvalidate(t *testing.T) {
+	ctx := context.Background()
+	var c permissionCache
+
+	fetchAll := func(ctx context.Context) (map[string][]permissionRow, error) {
+		return map[string][]permissionRow{"app": {{Role: "app", Resource: "<keyspace ks>", Permission: "SELECT"}}}, nil
+	}
+	rows, ok, err := c.getFromSnapshot(ctx, "app", fetchAll)
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.Len(t, rows, 1)
+
+	rows, ok, err = c.getFromSnapshot(ctx, "other", fetchAll)
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.Empty(t, rows)
+
+	c.invalidate("app")
+	_, ok, err = c.getFromSnapshot(ctx, "app", fetchAll)
+	require.NoError(t, err)
+	assert.False(t, ok)
+}
@@ -0,0 +1,116 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/scylladb/scylla-go-driver/frame"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces
+var _ tfsdk.DataSourceType = providerInfoDataSourceType{}
+var _ tfsdk.DataSource = providerInfoDataSource{}
+
+type providerInfoDataSourceType struct{}
+
+func (t providerInfoDataSourceType) GetSchema(ctx context.Context) (tfsdk.Schema, diag.Diagnostics) {
+	return tfsdk.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Describes the provider and the cluster it is connected to, " +
+			"for example to debug plans of CI agents or to check the minimum version of the server in a module.",
+
+		Attributes: map[string]tfsdk.Attribute{
+			"id": {
+				MarkdownDescription: "Always `provider_info`",
+				Computed:            true,
+				Type:                types.StringType,
+			},
+			"provider_version": {
+				MarkdownDescription: "Version of the provider, `dev` for builds that are not released.",
+				Computed:            true,
+				Type:                types.StringType,
+			},
+			"protocol_version": {
+				MarkdownDescription: "Version of the CQL native protocol used by the driver.",
+				Computed:            true,
+				Type:                types.Int64Type,
+			},
+			"host": {
+				MarkdownDescription: "RPC address of the node that answered the query.",
+				Computed:            true,
+				Type:                types.StringType,
+			},
+			"server_release": {
+				MarkdownDescription: "Scylla version of the node, for example `5.1.0` or `2022.1.3`.",
+				Computed:            true,
+				Type:                types.StringType,
+			},
+		},
+	}, nil
+}
+
+func (t providerInfoDataSourceType) NewDataSource(ctx context.Context, in tfsdk.Provider) (tfsdk.DataSource, diag.Diagnostics) {
+	provider, diags := convertProviderType(in)
+
+	return providerInfoDataSource{
+		provider: provider,
+	}, diags
+}
+
+type providerInfoDataSourceData struct {
+	Id              types.String `tfsdk:"id"`
+	ProviderVersion types.String `tfsdk:"provider_version"`
+	ProtocolVersion types.Int64  `tfsdk:"protocol_version"`
+	Host            types.String `tfsdk:"host"`
+	ServerRelease   types.String `tfsdk:"server_release"`
+}
+
+type providerInfoDataSource struct {
+	provider provider
+}
+
+func (d providerInfoDataSource) Read(ctx context.Context, req tfsdk.ReadDataSourceRequest, resp *tfsdk.ReadDataSourceResponse) {
+	data := providerInfoDataSourceData{
+		Id:              types.String{Value: "provider_info"},
+		ProviderVersion: types.String{Value: d.provider.version},
+		// The driver supports only version 4 of the protocol, it is never negotiated down.
+		ProtocolVersion: types.Int64{Value: int64(frame.CQLv4)},
+	}
+
+	result, err := d.provider.execute(ctx, "SELECT rpc_address FROM system.local", nil)
+	if err != nil {
+		resp.Diagnostics.Append(cqlErrorDiagnostic("Query error", "Unable to read the address of the node.", err))
+		return
+	}
+	if len(result.Rows) == 0 {
+		resp.Diagnostics.AddError("Query error", "Unable to read the address of the node: system.local is empty.")
+		return
+	}
+	host, err := cqlValueString(result.Rows[0][0])
+	if err != nil {
+		resp.Diagnostics.AddError("Query error", fmt.Sprintf("Unable to read the address of the node: %s", err))
+		return
+	}
+	data.Host = types.String{Value: host}
+
+	result, err = d.provider.execute(ctx, "SELECT version FROM system.versions WHERE key = 'local'", nil)
+	if err != nil {
+		resp.Diagnostics.Append(cqlErrorDiagnostic("Query error", "Unable to read the version of the node.", err))
+		return
+	}
+	if len(result.Rows) == 0 {
+		resp.Diagnostics.AddError("Query error", "Unable to read the version of the node: version not found.")
+		return
+	}
+	release, err := result.Rows[0][0].AsText()
+	if err != nil {
+		resp.Diagnostics.AddError("Query error", fmt.Sprintf("Unable to read the version of the node: %s", err))
+		return
+	}
+	data.ServerRelease = types.String{Value: release}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
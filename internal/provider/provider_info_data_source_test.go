@@ -0,0 +1,35 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProviderInfoDataSourceRead(t *testing.T) {
+	cluster := newFakeCluster()
+	p := cluster.provider()
+	p.version = "1.2.3"
+
+	ds := newTestDataSource(t, providerInfoDataSourceType{}, p)
+	resp := ds.read(&providerInfoDataSourceData{
+		Id:              types.String{Null: true},
+		ProviderVersion: types.String{Null: true},
+		ProtocolVersion: types.Int64{Null: true},
+		Host:            types.String{Null: true},
+		ServerRelease:   types.String{Null: true},
+	})
+	require.False(t, resp.Diagnostics.HasError(), "%v", resp.Diagnostics)
+
+	var data providerInfoDataSourceData
+	getTestState(t, resp.State, &data)
+	assert.Equal(t, providerInfoDataSourceData{
+		Id:              types.String{Value: "provider_info"},
+		ProviderVersion: types.String{Value: "1.2.3"},
+		ProtocolVersion: types.Int64{Value: 4},
+		Host:            types.String{Value: fakeHost},
+		ServerRelease:   types.String{Value: fakeVersion},
+	}, data)
+}
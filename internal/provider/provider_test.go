@@ -1,10 +1,17 @@
 package provider
 
 import (
+	"context"
+	"os"
+	"regexp"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
 	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 // testAccProtoV6ProviderFactories are used to instantiate a provider during
@@ -15,8 +22,135 @@ var testAccProtoV6ProviderFactories = map[string]func() (tfprotov6.ProviderServe
 	"scylla": providerserver.NewProtocol6WithError(New("test")()),
 }
 
+// testAccProtoV5ProviderFactories instantiate the provider served with protocol version 5,
+// as used by Terraform versions before 1.0.
+var testAccProtoV5ProviderFactories = map[string]func() (tfprotov5.ProviderServer, error){
+	"scylla": providerserver.NewProtocol5WithError(NewProtocol5("test")()),
+}
+
 func testAccPreCheck(t *testing.T) {
-	// You can add code here to run prior to any test case execution, for example assertions
-	// about the appropriate environment variables being set are common to see in a pre-check
-	// function.
+	if os.Getenv("SCYLLA_HOSTS") == "" {
+		t.Fatal("SCYLLA_HOSTS must be set for acceptance tests")
+	}
+}
+
+func TestParseHostPort(t *testing.T) {
+	tests := []struct {
+		hostport string
+		want     string
+		err      string
+	}{
+		{hostport: "scylla.internal", want: "scylla.internal:9042"},
+		{hostport: "scylla.internal:19042", want: "scylla.internal:19042"},
+		{hostport: "10.0.0.1", want: "10.0.0.1:9042"},
+		{hostport: "10.0.0.1:9142", want: "10.0.0.1:9142"},
+		{hostport: "::1", want: "[::1]:9042"},
+		{hostport: "2001:db8::1", want: "[2001:db8::1]:9042"},
+		{hostport: "fe80::1%eth0", want: "[fe80::1%eth0]:9042"},
+		{hostport: "[2001:db8::1]", want: "[2001:db8::1]:9042"},
+		{hostport: "[2001:db8::1]:9142", want: "[2001:db8::1]:9142"},
+		{hostport: "", err: "host must not be empty"},
+		{hostport: ":9042", err: "host must not be empty"},
+		{hostport: "10.0.0.1:0", err: "port must be a number between 1 and 65535"},
+		{hostport: "10.0.0.1:cql", err: "port must be a number between 1 and 65535"},
+		{hostport: "[2001:db8::1]:", err: "port must be a number between 1 and 65535"},
+		{hostport: "[2001:db8::1", err: "address [2001:db8::1: missing ']' in address"},
+		{hostport: "[scylla.internal]:9042", err: `"scylla.internal" is not a valid IPv6 address`},
+		{hostport: "10.0.0.1::9042", err: `"10.0.0.1::9042" is not a valid IPv6 address`},
+		{hostport: "[10.0.0.1]", err: `"10.0.0.1" is not a valid IPv6 address`},
+	}
+	for _, test := range tests {
+		err := validateHostPort(test.hostport)
+		if test.err != "" {
+			assert.EqualError(t, err, test.err, test.hostport)
+			continue
+		}
+		assert.NoError(t, err, test.hostport)
+		assert.Equal(t, test.want, addDefaultPort(test.hostport))
+	}
+}
+
+func TestStringOrEnv(t *testing.T) {
+	t.Setenv("SCYLLA_TEST_ENV", "from-env")
+	assert.Equal(t, "from-env", stringOrEnv(types.String{Null: true}, "SCYLLA_TEST_ENV"))
+	assert.Equal(t, "configured", stringOrEnv(types.String{Value: "configured"}, "SCYLLA_TEST_ENV"))
+	assert.Equal(t, "", stringOrEnv(types.String{Value: ""}, "SCYLLA_TEST_ENV"))
+}
+
+func TestValidateEnvAttributesKnown(t *testing.T) {
+	data := providerData{
+		Hosts:           types.String{Null: true},
+		Username:        types.String{Value: "cassandra"},
+		Password:        types.String{Unknown: true},
+		Socks5Proxy:     types.String{Null: true},
+		RestAPIEndpoint: types.String{Null: true},
+	}
+	diags := data.validateEnvAttributesKnown()
+	require.Len(t, diags, 1)
+	assert.Equal(t, "Unknown provider attribute", diags[0].Summary())
+	assert.Contains(t, diags[0].Detail(), "password")
+}
+
+func TestIsReadStatement(t *testing.T) {
+	assert.True(t, isReadStatement("SELECT role FROM system_auth.roles"))
+	assert.True(t, isReadStatement(" list all permissions of r"))
+	assert.True(t, isReadStatement("DESC SCHEMA"))
+	assert.False(t, isReadStatement("GRANT SELECT ON KEYSPACE ks TO r"))
+	assert.False(t, isReadStatement("CREATE ROLE r"))
+	assert.False(t, isReadStatement("SELECTED"))
+}
+
+func TestParseEdition(t *testing.T) {
+	assert.Equal(t, editionOpenSource, parseEdition("5.1.0-0.20221009.5ed5e1a2e"))
+	assert.Equal(t, editionEnterprise, parseEdition("2022.1.3-0.20220922.539a55e35"))
+	assert.Equal(t, editionUnknown, parseEdition("unknown"))
+}
+
+func TestProtocol5Schema(t *testing.T) {
+	server, err := testAccProtoV5ProviderFactories["scylla"]()
+	require.NoError(t, err)
+
+	resp, err := server.GetProviderSchema(context.Background(), &tfprotov5.GetProviderSchemaRequest{})
+	require.NoError(t, err)
+	for _, d := range resp.Diagnostics {
+		assert.NotEqual(t, tfprotov5.DiagnosticSeverityError, d.Severity, "%s: %s", d.Summary, d.Detail)
+	}
+	assert.Contains(t, resp.ResourceSchemas, "scylla_role")
+	assert.NotContains(t, resp.ResourceSchemas, "scylla_role_permissions")
+	assert.Contains(t, resp.DataSourceSchemas, "scylla_role")
+	assert.NotContains(t, resp.DataSourceSchemas, "scylla_types")
+}
+
+// TestProtocol5UnavailableDocumented checks that the README lists the resources and data sources
+// that are left out with protocol version 5.
+func TestProtocol5UnavailableDocumented(t *testing.T) {
+	ctx := context.Background()
+	p := New("test")().(*provider)
+
+	var unavailable []string
+	resources, diags := p.GetResources(ctx)
+	require.False(t, diags.HasError(), "%v", diags)
+	for name, typ := range resources {
+		if !protocol5Compatible(ctx, typ) {
+			unavailable = append(unavailable, name)
+		}
+	}
+	dataSources, diags := p.GetDataSources(ctx)
+	require.False(t, diags.HasError(), "%v", diags)
+	for name, typ := range dataSources {
+		if !protocol5Compatible(ctx, typ) {
+			unavailable = append(unavailable, name)
+		}
+	}
+
+	readme, err := os.ReadFile("../../README.md")
+	require.NoError(t, err)
+	m := regexp.MustCompile(`(?s)served over protocol version 5, without (.*?),\s+which need\s+protocol version 6`).
+		FindSubmatch(readme)
+	require.NotNil(t, m, "list of resources not available with protocol version 5 not found in README.md")
+	var documented []string
+	for _, name := range regexp.MustCompile("`(scylla_\\w+)`").FindAllSubmatch(m[1], -1) {
+		documented = append(documented, string(name[1]))
+	}
+	assert.ElementsMatch(t, unavailable, documented)
 }
@@ -0,0 +1,102 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+	"sync"
+
+	"github.com/scylladb/scylla-go-driver/transport"
+	"golang.org/x/net/proxy"
+)
+
+// parseProxy returns a dialer connecting through the SOCKS5 proxy at the given address.
+// The address is either host:port or a socks5:// URL, which can contain credentials.
+func parseProxy(address string) (proxy.ContextDialer, error) {
+	if !strings.Contains(address, "://") {
+		address = "socks5://" + address
+	}
+	u, err := url.Parse(address)
+	if err != nil {
+		return nil, err
+	}
+	if u.Scheme != "socks5" && u.Scheme != "socks5h" {
+		return nil, fmt.Errorf("unsupported proxy scheme %q, only socks5 is supported", u.Scheme)
+	}
+	d, err := proxy.FromURL(u, proxy.Direct)
+	if err != nil {
+		return nil, err
+	}
+	cd, ok := d.(proxy.ContextDialer)
+	if !ok {
+		return nil, fmt.Errorf("proxy dialer does not support contexts")
+	}
+	return cd, nil
+}
+
+// openProxyConn opens a connection to the first reachable host through s.dialer.
+// The driver cannot route its connection pools through a proxy, so only a single
+// connection to one of the configured hosts is used. The hosts are tried in the order of s.hostSelection.
+func (s *session) openProxyConn() (*transport.Conn, error) {
+	var errs hostErrors
+	for _, hostport := range s.hostSelection.orderHosts(s.hosts) {
+		// The connection outlives the request that opened it.
+		conn, err := s.openConn(context.Background(), hostport)
+		if err != nil {
+			errs = append(errs, hostError{hostport: hostport, err: err})
+			continue
+		}
+		return conn, nil
+	}
+	return nil, errs
+}
+
+// probeHosts opens and closes a connection to every host concurrently
+// and returns the errors of the hosts that could not be connected to.
+func (s *session) probeHosts(ctx context.Context) hostErrors {
+	results := make([]error, len(s.hosts))
+	var wg sync.WaitGroup
+	for i, hostport := range s.hosts {
+		wg.Add(1)
+		go func(i int, hostport string) {
+			defer wg.Done()
+			conn, err := s.openConn(ctx, hostport)
+			if err != nil {
+				results[i] = err
+				return
+			}
+			conn.Close()
+		}(i, hostport)
+	}
+	wg.Wait()
+
+	var errs hostErrors
+	for i, err := range results {
+		if err != nil {
+			errs = append(errs, hostError{hostport: s.hosts[i], err: err})
+		}
+	}
+	return errs
+}
+
+// openConn opens a single connection to the host, through s.dialer if it is set.
+func (s *session) openConn(ctx context.Context, hostport string) (*transport.Conn, error) {
+	if s.dialer == nil {
+		return transport.OpenConn(ctx, hostport, nil, s.connConfig)
+	}
+	nc, err := s.dialer.DialContext(ctx, "tcp", hostport)
+	if err != nil {
+		return nil, fmt.Errorf("dial through proxy: %w", err)
+	}
+	conn, err := transport.WrapConn(ctx, nc, s.connConfig)
+	if err != nil {
+		if conn != nil {
+			conn.Close()
+		} else {
+			nc.Close()
+		}
+		return nil, err
+	}
+	return conn, nil
+}
@@ -0,0 +1,203 @@
+package provider
+
+import (
+	"context"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/scylladb/scylla-go-driver/frame"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces
+var _ tfsdk.DataSourceType = queryDataSourceType{}
+var _ tfsdk.DataSource = queryDataSource{}
+
+type queryDataSourceType struct{}
+
+func (t queryDataSourceType) GetSchema(ctx context.Context) (tfsdk.Schema, diag.Diagnostics) {
+	return tfsdk.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Runs an arbitrary SELECT statement and returns the rows",
+
+		Attributes: map[string]tfsdk.Attribute{
+			"statement": {
+				MarkdownDescription: "The SELECT statement to run. Use `?` as placeholders for `values`.",
+				Required:            true,
+				Type:                types.StringType,
+			},
+			"values": {
+				MarkdownDescription: "Values bound to the placeholders of the statement. All values are bound as text.",
+				Optional:            true,
+				Type:                types.ListType{ElemType: types.StringType},
+			},
+			"id": {
+				MarkdownDescription: "Identifier of the data source",
+				Computed:            true,
+				Type:                types.StringType,
+			},
+			"rows": {
+				MarkdownDescription: "Rows returned by the statement. Each row maps column names to values " +
+					"converted to strings, null values are omitted. Collections are rendered as JSON.",
+				Computed: true,
+				Type:     types.ListType{ElemType: types.MapType{ElemType: types.StringType}},
+			},
+		},
+	}, nil
+}
+
+func (t queryDataSourceType) NewDataSource(ctx context.Context, in tfsdk.Provider) (tfsdk.DataSource, diag.Diagnostics) {
+	provider, diags := convertProviderType(in)
+
+	return queryDataSource{
+		provider: provider,
+	}, diags
+}
+
+type queryDataSourceData struct {
+	Statement types.String `tfsdk:"statement"`
+	Values    []string     `tfsdk:"values"`
+	Id        types.String `tfsdk:"id"`
+	Rows      types.List   `tfsdk:"rows"`
+}
+
+type queryDataSource struct {
+	provider provider
+}
+
+func (d queryDataSource) Read(ctx context.Context, req tfsdk.ReadDataSourceRequest, resp *tfsdk.ReadDataSourceResponse) {
+	var data queryDataSourceData
+
+	diags := req.Config.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !strings.HasPrefix(strings.ToUpper(strings.TrimSpace(data.Statement.Value)), "SELECT") {
+		resp.Diagnostics.AddAttributeError(path.Root("statement"), "Unsupported statement",
+			"Only SELECT statements can be run by the scylla_query data source.")
+		return
+	}
+
+	values := make([]frame.CqlValue, len(data.Values))
+	for i := range data.Values {
+		v, err := frame.CqlFromText(data.Values[i])
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("values").AtListIndex(i), "Cannot convert value",
+				err.Error())
+			return
+		}
+		values[i] = v
+	}
+
+	result, err := d.provider.execute(ctx, data.Statement.Value, values)
+	if err != nil {
+		resp.Diagnostics.Append(cqlErrorDiagnostic("Query error", data.Statement.Value, err))
+		return
+	}
+	resp.Diagnostics.Append(warningDiagnostics(result.Warnings)...)
+
+	rowType := types.MapType{ElemType: types.StringType}
+	data.Rows = types.List{ElemType: rowType, Elems: make([]attr.Value, 0, len(result.Rows))}
+	for i := range result.Rows {
+		row := types.Map{ElemType: types.StringType, Elems: make(map[string]attr.Value)}
+		for j := range result.ColSpec {
+			v := result.Rows[i][j]
+			if v.Value == nil {
+				continue
+			}
+			s, err := cqlValueString(v)
+			if err != nil {
+				resp.Diagnostics.AddError("Query result error",
+					fmt.Sprintf("Unable to read column %q of row %d: %s", result.ColSpec[j].Name, i, err))
+				return
+			}
+			row.Elems[result.ColSpec[j].Name] = types.String{Value: s}
+		}
+		data.Rows.Elems = append(data.Rows.Elems, row)
+	}
+
+	data.Id = data.Statement
+
+	diags = resp.State.Set(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+}
+
+// cqlValueString converts a non-null CQL value to its string representation.
+func cqlValueString(v frame.CqlValue) (string, error) {
+	switch v.Type.ID {
+	case frame.ASCIIID, frame.VarcharID:
+		return string(v.Value), nil
+	case frame.BooleanID:
+		b, err := v.AsBoolean()
+		return strconv.FormatBool(b), err
+	case frame.BigIntID, frame.CounterID, frame.TimestampID:
+		if len(v.Value) != 8 {
+			return "", fmt.Errorf("expected 8 bytes, got %d", len(v.Value))
+		}
+		i := int64(binary.BigEndian.Uint64(v.Value))
+		if v.Type.ID == frame.TimestampID {
+			return time.UnixMilli(i).UTC().Format(time.RFC3339Nano), nil
+		}
+		return strconv.FormatInt(i, 10), nil
+	case frame.IntID:
+		i, err := v.AsInt32()
+		return strconv.FormatInt(int64(i), 10), err
+	case frame.SmallIntID:
+		i, err := v.AsInt16()
+		return strconv.FormatInt(int64(i), 10), err
+	case frame.TinyIntID:
+		i, err := v.AsInt8()
+		return strconv.FormatInt(int64(i), 10), err
+	case frame.FloatID:
+		f, err := v.AsFloat32()
+		return strconv.FormatFloat(float64(f), 'g', -1, 32), err
+	case frame.DoubleID:
+		f, err := v.AsFloat64()
+		return strconv.FormatFloat(f, 'g', -1, 64), err
+	case frame.UUIDID, frame.TimeUUIDID:
+		if len(v.Value) != 16 {
+			return "", fmt.Errorf("expected 16 bytes, got %d", len(v.Value))
+		}
+		h := hex.EncodeToString(v.Value)
+		return fmt.Sprintf("%s-%s-%s-%s-%s", h[0:8], h[8:12], h[12:16], h[16:20], h[20:32]), nil
+	case frame.InetID:
+		ip, err := v.AsIP()
+		return ip.String(), err
+	case frame.BlobID:
+		return "0x" + hex.EncodeToString(v.Value), nil
+	case frame.DurationID:
+		d, err := v.AsDuration()
+		if err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("%dmo%dd%dns", d.Months, d.Days, d.Nanoseconds), nil
+	case frame.ListID, frame.SetID:
+		s, err := v.AsStringSlice()
+		if err != nil {
+			return "", err
+		}
+		b, err := json.Marshal(s)
+		return string(b), err
+	case frame.MapID:
+		m, err := v.AsStringMap()
+		if err != nil {
+			return "", err
+		}
+		b, err := json.Marshal(m)
+		return string(b), err
+	default:
+		return "", fmt.Errorf("unsupported type %#x", v.Type.ID)
+	}
+}
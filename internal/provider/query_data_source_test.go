@@ -0,0 +1,38 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/scylladb/scylla-go-driver/frame"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCqlValueString(t *testing.T) {
+	text, err := frame.CqlFromText("hello")
+	require.NoError(t, err)
+
+	tests := []struct {
+		name     string
+		value    frame.CqlValue
+		expected string
+	}{
+		{"text", text, "hello"},
+		{"boolean", frame.CqlFromBoolean(true), "true"},
+		{"bigint", frame.CqlFromInt64(-42), "-42"},
+		{"int", frame.CqlFromInt32(7), "7"},
+		{"double", frame.CqlFromFloat64(1.5), "1.5"},
+		{"blob", frame.CqlFromBlob([]byte{0xca, 0xfe}), "0xcafe"},
+		{"uuid", frame.CqlFromUUID([16]byte{0x12, 0x3e, 0x45, 0x67, 0xe8, 0x9b, 0x12, 0xd3,
+			0xa4, 0x56, 0x42, 0x66, 0x14, 0x17, 0x40, 0x00}), "123e4567-e89b-12d3-a456-426614174000"},
+		{"timestamp", frame.CqlValue{Type: &frame.Option{ID: frame.TimestampID},
+			Value: frame.CqlFromInt64(1000).Value}, "1970-01-01T00:00:01Z"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			s, err := cqlValueString(test.value)
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, s)
+		})
+	}
+}
@@ -0,0 +1,147 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+)
+
+// restAPIPort is the port the Scylla REST API listens on by default.
+const restAPIPort = "10000"
+
+// restClient queries the REST API of a single Scylla node, which is served on a separate port from CQL
+// and exposes node state that is not available through CQL, such as gossip.
+type restClient struct {
+	// endpoint is the base URL of the API, for example http://10.0.0.1:10000.
+	endpoint string
+
+	client *http.Client
+}
+
+// newRESTClient returns a client of the API at endpoint. If dial is not nil, connections are opened with it,
+// so that the API is reached through the same proxy as the cluster.
+func newRESTClient(endpoint string, dial func(ctx context.Context, network, address string) (net.Conn, error)) (*restClient, error) {
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return nil, err
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return nil, fmt.Errorf("endpoint must be an http or https URL, got %q", endpoint)
+	}
+	if u.Host == "" {
+		return nil, fmt.Errorf("endpoint must include a host, got %q", endpoint)
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	if dial != nil {
+		transport.Proxy = nil
+		transport.DialContext = dial
+	}
+	return &restClient{
+		endpoint: strings.TrimSuffix(endpoint, "/"),
+		client:   &http.Client{Transport: transport, Timeout: 30 * time.Second},
+	}, nil
+}
+
+// defaultRESTEndpoint returns the endpoint of the API on the node of hostport, the first configured host.
+func defaultRESTEndpoint(hostport string) string {
+	host, _, err := net.SplitHostPort(hostport)
+	if err != nil {
+		host = hostport
+	}
+	return "http://" + net.JoinHostPort(host, restAPIPort)
+}
+
+// get fetches the API path and decodes the JSON response into v.
+func (c *restClient) get(ctx context.Context, path string, v interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.endpoint+path, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("GET %s: %s: %s", path, resp.Status, strings.TrimSpace(string(body)))
+	}
+	if err := json.NewDecoder(resp.Body).Decode(v); err != nil {
+		return fmt.Errorf("GET %s: decoding response: %w", path, err)
+	}
+	return nil
+}
+
+// restKeyValue is an entry of a map as encoded by the API.
+type restKeyValue struct {
+	Key   string  `json:"key"`
+	Value float64 `json:"value"`
+}
+
+// nodeStatus is the state of a node as seen by the node the API is queried on.
+type nodeStatus struct {
+	address string
+	// status is UP or DOWN according to gossip, UNKNOWN if gossip has no state of the node.
+	status string
+	// load is the size of data on the node in bytes.
+	load int64
+}
+
+// nodeStatuses returns the state of all nodes known to gossip, sorted by address.
+func (c *restClient) nodeStatuses(ctx context.Context) ([]nodeStatus, error) {
+	var live, down []string
+	if err := c.get(ctx, "/gossiper/endpoint/live/", &live); err != nil {
+		return nil, err
+	}
+	if err := c.get(ctx, "/gossiper/endpoint/down/", &down); err != nil {
+		return nil, err
+	}
+	var loads []restKeyValue
+	if err := c.get(ctx, "/storage_service/load_map", &loads); err != nil {
+		return nil, err
+	}
+
+	nodes := make(map[string]*nodeStatus)
+	for _, address := range live {
+		nodes[address] = &nodeStatus{address: address, status: "UP"}
+	}
+	for _, address := range down {
+		nodes[address] = &nodeStatus{address: address, status: "DOWN"}
+	}
+	for _, kv := range loads {
+		n, ok := nodes[kv.Key]
+		if !ok {
+			// Known to the token metadata only, gossip has no state of it.
+			n = &nodeStatus{address: kv.Key, status: "UNKNOWN"}
+			nodes[kv.Key] = n
+		}
+		n.load = int64(kv.Value)
+	}
+
+	result := make([]nodeStatus, 0, len(nodes))
+	for _, n := range nodes {
+		result = append(result, *n)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].address < result[j].address })
+	return result, nil
+}
+
+// uptime returns the time since the queried node started.
+func (c *restClient) uptime(ctx context.Context) (time.Duration, error) {
+	var ms int64
+	if err := c.get(ctx, "/system/uptime_ms", &ms); err != nil {
+		return 0, err
+	}
+	return time.Duration(ms) * time.Millisecond, nil
+}
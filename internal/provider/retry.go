@@ -0,0 +1,151 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/scylladb/scylla-go-driver/frame"
+	"github.com/scylladb/scylla-go-driver/frame/response"
+)
+
+// retryableErrors maps names of error classes that can be retried to their protocol error codes.
+var retryableErrors = map[string]frame.ErrorCode{
+	"unavailable":   frame.ErrCodeUnavailable,
+	"overloaded":    frame.ErrCodeOverloaded,
+	"bootstrapping": frame.ErrCodeBootstrapping,
+	"read_timeout":  frame.ErrCodeReadTimeout,
+	"write_timeout": frame.ErrCodeWriteTimeout,
+	"read_failure":  frame.ErrCodeReadFailure,
+	"write_failure": frame.ErrCodeWriteFailure,
+}
+
+var defaultRetryOn = []string{"unavailable", "overloaded", "bootstrapping", "read_timeout", "write_timeout"}
+
+// unappliedErrors are the error codes returned before the coordinator started executing the statement.
+// Statements failed with them can be retried even if they are not idempotent.
+var unappliedErrors = map[frame.ErrorCode]struct{}{
+	frame.ErrCodeUnavailable:   {},
+	frame.ErrCodeOverloaded:    {},
+	frame.ErrCodeBootstrapping: {},
+}
+
+// retryPolicy decides whether and when a failed statement is executed again.
+type retryPolicy struct {
+	// maxAttempts is the maximum number of executions of a statement, including the first one.
+	maxAttempts int
+
+	// baseBackoff is the delay before the first retry, it doubles with every further attempt.
+	baseBackoff time.Duration
+
+	// maxBackoff caps the delay between attempts.
+	maxBackoff time.Duration
+
+	// retryOn holds error codes that are retried.
+	retryOn map[frame.ErrorCode]struct{}
+}
+
+func newRetryPolicy(maxAttempts int, baseBackoff, maxBackoff time.Duration, retryOn []string) (retryPolicy, error) {
+	if maxAttempts < 1 {
+		return retryPolicy{}, fmt.Errorf("max attempts must be at least 1")
+	}
+	if baseBackoff < 0 || maxBackoff < 0 {
+		return retryPolicy{}, fmt.Errorf("backoff must not be negative")
+	}
+	if maxBackoff < baseBackoff {
+		return retryPolicy{}, fmt.Errorf("max backoff must not be less than base backoff")
+	}
+	codes := make(map[frame.ErrorCode]struct{}, len(retryOn))
+	for _, name := range retryOn {
+		code, ok := retryableErrors[name]
+		if !ok {
+			names := make([]string, 0, len(retryableErrors))
+			for k := range retryableErrors {
+				names = append(names, k)
+			}
+			sort.Strings(names)
+			return retryPolicy{}, fmt.Errorf("unsupported error class %q, must be one of %s", name, names)
+		}
+		codes[code] = struct{}{}
+	}
+	return retryPolicy{
+		maxAttempts: maxAttempts,
+		baseBackoff: baseBackoff,
+		maxBackoff:  maxBackoff,
+		retryOn:     codes,
+	}, nil
+}
+
+// shouldRetry reports whether a statement that failed with err on the given attempt
+// (starting from 1) should be executed again. Statements that failed because of a broken
+// connection, a timeout or a failure of replicas are retried only if they are idempotent,
+// as they might have been applied already.
+func (r retryPolicy) shouldRetry(attempt int, err error, idempotent bool) bool {
+	if attempt >= r.maxAttempts {
+		return false
+	}
+	if isConnectionError(err) {
+		return idempotent
+	}
+	var codedErr response.CodedError
+	if !errors.As(err, &codedErr) {
+		return false
+	}
+	if _, ok := r.retryOn[codedErr.ErrorCode()]; !ok {
+		return false
+	}
+	if _, ok := unappliedErrors[codedErr.ErrorCode()]; ok {
+		return true
+	}
+	return idempotent
+}
+
+// backoff returns the delay before the next attempt after the given attempt failed.
+func (r retryPolicy) backoff(attempt int) time.Duration {
+	d := r.baseBackoff
+	for i := 1; i < attempt && d < r.maxBackoff; i++ {
+		d *= 2
+	}
+	if d > r.maxBackoff {
+		d = r.maxBackoff
+	}
+	return d
+}
+
+// isConnectionError reports whether err was caused by a broken or unavailable connection
+// rather than by the server rejecting the statement.
+func isConnectionError(err error) bool {
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+	var codedErr response.CodedError
+	return !errors.As(err, &codedErr)
+}
+
+// idempotentPrefixes are the leading keywords of statements that can be safely executed more than once.
+var idempotentPrefixes = []string{"SELECT", "LIST", "DESC", "GRANT", "REVOKE"}
+
+// conditionalDDLPrefixes are the leading keywords of schema and role statements that are idempotent
+// if they are conditional, such as CREATE ROLE IF NOT EXISTS.
+var conditionalDDLPrefixes = []string{"CREATE", "DROP", "ALTER"}
+
+// isIdempotent reports whether executing the statement more than once has the same effect as executing it once.
+// Conditional INSERT, UPDATE and DELETE statements are lightweight transactions, which are not idempotent:
+// a retry of an applied one is not applied, and the condition might have changed in the meantime.
+func isIdempotent(query string) bool {
+	upper := strings.ToUpper(strings.TrimSpace(query))
+	for _, prefix := range idempotentPrefixes {
+		if strings.HasPrefix(upper, prefix) {
+			return true
+		}
+	}
+	for _, prefix := range conditionalDDLPrefixes {
+		if strings.HasPrefix(upper, prefix) {
+			return strings.Contains(upper, " IF NOT EXISTS") || strings.Contains(upper, " IF EXISTS")
+		}
+	}
+	return false
+}
@@ -0,0 +1,95 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/scylladb/scylla-go-driver/frame"
+	"github.com/scylladb/scylla-go-driver/frame/response"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRetryPolicy_Backoff(t *testing.T) {
+	r, err := newRetryPolicy(10, 100*time.Millisecond, time.Second, nil)
+	require.NoError(t, err)
+	assert.Equal(t, 100*time.Millisecond, r.backoff(1))
+	assert.Equal(t, 200*time.Millisecond, r.backoff(2))
+	assert.Equal(t, 800*time.Millisecond, r.backoff(4))
+	assert.Equal(t, time.Second, r.backoff(5))
+	assert.Equal(t, time.Second, r.backoff(9))
+}
+
+func TestRetryPolicy_ShouldRetry(t *testing.T) {
+	r, err := newRetryPolicy(3, 0, 0, []string{"overloaded"})
+	require.NoError(t, err)
+
+	overloaded := response.ScyllaError{Code: frame.ErrCodeOverloaded}
+	syntax := response.ScyllaError{Code: frame.ErrCodeSyntax}
+
+	assert.True(t, r.shouldRetry(1, overloaded, false))
+	assert.True(t, r.shouldRetry(2, fmt.Errorf("wrapped: %w", overloaded), false))
+	assert.False(t, r.shouldRetry(3, overloaded, false))
+	assert.False(t, r.shouldRetry(1, syntax, true))
+	assert.False(t, r.shouldRetry(1, fmt.Errorf("dial tcp: connection refused"), false))
+	assert.True(t, r.shouldRetry(1, fmt.Errorf("dial tcp: connection refused"), true))
+	assert.False(t, r.shouldRetry(3, fmt.Errorf("read header: EOF"), true))
+	assert.False(t, r.shouldRetry(1, context.Canceled, true))
+}
+
+func TestRetryPolicy_ShouldRetryIdempotent(t *testing.T) {
+	r, err := newRetryPolicy(3, 0, 0, defaultRetryOn)
+	require.NoError(t, err)
+
+	unavailable := response.ScyllaError{Code: frame.ErrCodeUnavailable}
+	writeTimeout := response.ScyllaError{Code: frame.ErrCodeWriteTimeout}
+	readTimeout := response.ScyllaError{Code: frame.ErrCodeReadTimeout}
+
+	// The coordinator did not execute the statement.
+	assert.True(t, r.shouldRetry(1, unavailable, false))
+	assert.True(t, r.shouldRetry(1, unavailable, true))
+
+	// The statement might have been applied.
+	assert.False(t, r.shouldRetry(1, writeTimeout, false))
+	assert.True(t, r.shouldRetry(1, writeTimeout, true))
+	assert.False(t, r.shouldRetry(1, readTimeout, false))
+	assert.True(t, r.shouldRetry(1, readTimeout, true))
+}
+
+func TestIsIdempotent(t *testing.T) {
+	assert.True(t, isIdempotent("SELECT * FROM system_auth.roles"))
+	assert.True(t, isIdempotent("  list all permissions of r"))
+	assert.True(t, isIdempotent("GRANT SELECT ON KEYSPACE ks TO r"))
+	assert.True(t, isIdempotent("CREATE ROLE IF NOT EXISTS r"))
+	assert.True(t, isIdempotent("DROP ROLE IF EXISTS r"))
+	assert.False(t, isIdempotent("CREATE ROLE r"))
+	assert.False(t, isIdempotent("DROP ROLE r"))
+	assert.False(t, isIdempotent("INSERT INTO ks.t (k) VALUES (1) IF NOT EXISTS"))
+	assert.False(t, isIdempotent("UPDATE ks.t SET v = 1 WHERE k = 1 IF EXISTS"))
+	assert.False(t, isIdempotent("DELETE FROM ks.t WHERE k = 1 IF EXISTS"))
+}
+
+func TestRetryPolicy_ShouldRetryConditionalInsert(t *testing.T) {
+	r, err := newRetryPolicy(3, 0, 0, defaultRetryOn)
+	require.NoError(t, err)
+
+	// The insert might have been applied by the first attempt, a retry would then report it as not applied.
+	insert := isIdempotent("INSERT INTO ks.t (k) VALUES (1) IF NOT EXISTS")
+	assert.False(t, r.shouldRetry(1, response.ScyllaError{Code: frame.ErrCodeWriteTimeout}, insert))
+	assert.False(t, r.shouldRetry(1, fmt.Errorf("read header: EOF"), insert))
+	assert.True(t, r.shouldRetry(1, response.ScyllaError{Code: frame.ErrCodeUnavailable}, insert))
+
+	create := isIdempotent("CREATE ROLE IF NOT EXISTS r")
+	assert.True(t, r.shouldRetry(1, response.ScyllaError{Code: frame.ErrCodeWriteTimeout}, create))
+}
+
+func TestNewRetryPolicy_Invalid(t *testing.T) {
+	_, err := newRetryPolicy(0, 0, 0, nil)
+	assert.Error(t, err)
+	_, err = newRetryPolicy(1, time.Second, time.Millisecond, nil)
+	assert.Error(t, err)
+	_, err = newRetryPolicy(1, 0, 0, []string{"syntax"})
+	assert.Error(t, err)
+}
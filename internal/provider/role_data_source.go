@@ -0,0 +1,108 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces
+var _ tfsdk.DataSourceType = roleDataSourceType{}
+var _ tfsdk.DataSource = roleDataSource{}
+
+type roleDataSourceType struct{}
+
+func (t roleDataSourceType) GetSchema(ctx context.Context) (tfsdk.Schema, diag.Diagnostics) {
+	return tfsdk.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Looks up an existing Scylla role",
+
+		Attributes: map[string]tfsdk.Attribute{
+			"name": {
+				MarkdownDescription: "Name of the role",
+				Required:            true,
+				Type:                types.StringType,
+			},
+			"id": {
+				MarkdownDescription: "ID of the role",
+				Computed:            true,
+				Type:                types.StringType,
+			},
+			"login": {
+				MarkdownDescription: "Indicates whether the role is allowed to login.",
+				Computed:            true,
+				Type:                types.BoolType,
+			},
+			"superuser": {
+				MarkdownDescription: "Indicates whether the role has all permissions.",
+				Computed:            true,
+				Type:                types.BoolType,
+			},
+			"member_of": {
+				MarkdownDescription: "Names of the roles this role is directly granted.",
+				Computed:            true,
+				Type:                types.SetType{ElemType: types.StringType},
+			},
+		},
+	}, nil
+}
+
+func (t roleDataSourceType) NewDataSource(ctx context.Context, in tfsdk.Provider) (tfsdk.DataSource, diag.Diagnostics) {
+	provider, diags := convertProviderType(in)
+
+	return roleDataSource{
+		provider: provider,
+	}, diags
+}
+
+type roleDataSourceData struct {
+	Name      types.String `tfsdk:"name"`
+	Id        types.String `tfsdk:"id"`
+	Login     types.Bool   `tfsdk:"login"`
+	Superuser types.Bool   `tfsdk:"superuser"`
+	MemberOf  types.Set    `tfsdk:"member_of"`
+}
+
+type roleDataSource struct {
+	provider provider
+}
+
+func (d roleDataSource) Read(ctx context.Context, req tfsdk.ReadDataSourceRequest, resp *tfsdk.ReadDataSourceResponse) {
+	var data roleDataSourceData
+
+	diags := req.Config.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	info, found, err := d.provider.readRole(ctx, data.Name.Value)
+	if err != nil {
+		resp.Diagnostics.Append(cqlErrorDiagnostic("Query error", "Unable to read role info.", err))
+		return
+	}
+
+	if !found {
+		resp.Diagnostics.AddAttributeError(path.Root("name"), "Role not found",
+			fmt.Sprintf("Role %q does not exist.", data.Name.Value))
+		return
+	}
+
+	data.MemberOf = types.Set{ElemType: types.StringType, Elems: []attr.Value{}}
+	for _, role := range info.memberOf {
+		data.MemberOf.Elems = append(data.MemberOf.Elems, types.String{Value: role})
+	}
+
+	data.Id = data.Name
+	data.Login = types.Bool{Value: info.login}
+	data.Superuser = types.Bool{Value: info.superuser}
+
+	diags = resp.State.Set(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+}
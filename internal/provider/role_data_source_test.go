@@ -0,0 +1,38 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccRoleDataSource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Read testing
+			{
+				Config: testAccRoleDataSourceConfig,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.scylla_role.test", "id", "ds_role"),
+					resource.TestCheckResourceAttr("data.scylla_role.test", "login", "true"),
+					resource.TestCheckResourceAttr("data.scylla_role.test", "superuser", "false"),
+					resource.TestCheckResourceAttr("data.scylla_role.test", "member_of.#", "0"),
+				),
+			},
+		},
+	})
+}
+
+const testAccRoleDataSourceConfig = `
+resource "scylla_role" "test" {
+  name      = "ds_role"
+  login     = true
+  superuser = false
+}
+
+data "scylla_role" "test" {
+  name = scylla_role.test.name
+}
+`
@@ -0,0 +1,203 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/kiwicom/terraform-provider-scylla/internal/qb"
+	"github.com/kiwicom/terraform-provider-scylla/internal/scan"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces
+var _ tfsdk.DataSourceType = roleGrantsDataSourceType{}
+var _ tfsdk.DataSource = roleGrantsDataSource{}
+
+type roleGrantsDataSourceType struct{}
+
+func (t roleGrantsDataSourceType) GetSchema(ctx context.Context) (tfsdk.Schema, diag.Diagnostics) {
+	return tfsdk.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Lists all permissions of a role, including the ones inherited from granted roles",
+
+		Attributes: map[string]tfsdk.Attribute{
+			"role": {
+				MarkdownDescription: "Name of the role",
+				Required:            true,
+				Type:                types.StringType,
+			},
+			"id": {
+				MarkdownDescription: "Identifier of the data source",
+				Computed:            true,
+				Type:                types.StringType,
+			},
+			"grants": {
+				MarkdownDescription: "Permissions of the role in the order returned by the server.",
+				Computed:            true,
+				Attributes: tfsdk.ListNestedAttributes(map[string]tfsdk.Attribute{
+					"grantee": {
+						MarkdownDescription: "Role the permission is granted to directly. " +
+							"Differs from `role` when the permission is inherited.",
+						Computed: true,
+						Type:     types.StringType,
+					},
+					"resource": {
+						MarkdownDescription: "Resource as printed by the server, for example `<table ks.tbl>`.",
+						Computed:            true,
+						Type:                types.StringType,
+					},
+					"resource_kind": {
+						MarkdownDescription: "Kind of the resource. One of `all_keyspaces`, `keyspace`, `table`, " +
+							"`all_roles`, `role`, `all_functions`, `function` or `unknown`.",
+						Computed: true,
+						Type:     types.StringType,
+					},
+					"keyspace": {
+						MarkdownDescription: "Keyspace of the resource, if any.",
+						Computed:            true,
+						Type:                types.StringType,
+					},
+					"table": {
+						MarkdownDescription: "Table of the resource, if any.",
+						Computed:            true,
+						Type:                types.StringType,
+					},
+					"target_role": {
+						MarkdownDescription: "Role the resource refers to, if the resource is a role.",
+						Computed:            true,
+						Type:                types.StringType,
+					},
+					"permission": {
+						MarkdownDescription: "The permission that is granted.",
+						Computed:            true,
+						Type:                types.StringType,
+					},
+				}),
+			},
+		},
+	}, nil
+}
+
+func (t roleGrantsDataSourceType) NewDataSource(ctx context.Context, in tfsdk.Provider) (tfsdk.DataSource, diag.Diagnostics) {
+	provider, diags := convertProviderType(in)
+
+	return roleGrantsDataSource{
+		provider: provider,
+	}, diags
+}
+
+type roleGrantsDataSourceData struct {
+	Role   types.String                    `tfsdk:"role"`
+	Id     types.String                    `tfsdk:"id"`
+	Grants []roleGrantsDataSourceGrantData `tfsdk:"grants"`
+}
+
+type roleGrantsDataSourceGrantData struct {
+	Grantee      types.String `tfsdk:"grantee"`
+	Resource     types.String `tfsdk:"resource"`
+	ResourceKind types.String `tfsdk:"resource_kind"`
+	Keyspace     types.String `tfsdk:"keyspace"`
+	Table        types.String `tfsdk:"table"`
+	TargetRole   types.String `tfsdk:"target_role"`
+	Permission   types.String `tfsdk:"permission"`
+}
+
+type roleGrantsDataSource struct {
+	provider provider
+}
+
+func (d roleGrantsDataSource) Read(ctx context.Context, req tfsdk.ReadDataSourceRequest, resp *tfsdk.ReadDataSourceResponse) {
+	var data roleGrantsDataSourceData
+
+	diags := req.Config.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var stmt qb.Builder
+	stmt.Appendf("LIST ALL PERMISSIONS OF %s", qb.QName(data.Role.Value))
+
+	result, err := d.provider.executeAuthRead(ctx, stmt.String(), nil)
+	if err != nil {
+		resp.Diagnostics.Append(cqlErrorDiagnostic("Query error",
+			fmt.Sprintf("Unable to list permissions:\n%s", stmt.String()), err))
+		return
+	}
+
+	var rows []permissionRow
+	if err := scan.Rows(result, &rows); err != nil {
+		resp.Diagnostics.AddError("Query error", err.Error())
+		return
+	}
+
+	data.Grants = make([]roleGrantsDataSourceGrantData, 0, len(rows))
+	for _, row := range rows {
+		parsed := parseListResource(row.Resource)
+		grant := roleGrantsDataSourceGrantData{
+			Grantee:      types.String{Value: row.Role},
+			Resource:     types.String{Value: row.Resource},
+			ResourceKind: types.String{Value: parsed.kind},
+			Keyspace:     types.String{Null: true},
+			Table:        types.String{Null: true},
+			TargetRole:   types.String{Null: true},
+			Permission:   types.String{Value: row.Permission},
+		}
+		if parsed.keyspace != "" {
+			grant.Keyspace = types.String{Value: parsed.keyspace}
+		}
+		if parsed.table != "" {
+			grant.Table = types.String{Value: parsed.table}
+		}
+		if parsed.role != "" {
+			grant.TargetRole = types.String{Value: parsed.role}
+		}
+		data.Grants = append(data.Grants, grant)
+	}
+
+	data.Id = data.Role
+
+	diags = resp.State.Set(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+}
+
+// listResourceInfo is a resource as printed by LIST PERMISSIONS split into its parts.
+type listResourceInfo struct {
+	kind     string
+	keyspace string
+	table    string
+	role     string
+}
+
+// parseListResource parses the resource column of LIST PERMISSIONS,
+// for example "<table ks.tbl>" or "<all keyspaces>".
+func parseListResource(s string) listResourceInfo {
+	inner := strings.TrimSuffix(strings.TrimPrefix(s, "<"), ">")
+	switch {
+	case inner == "all keyspaces":
+		return listResourceInfo{kind: "all_keyspaces"}
+	case strings.HasPrefix(inner, "keyspace "):
+		return listResourceInfo{kind: "keyspace", keyspace: strings.TrimPrefix(inner, "keyspace ")}
+	case strings.HasPrefix(inner, "table "):
+		ks, table, _ := strings.Cut(strings.TrimPrefix(inner, "table "), ".")
+		return listResourceInfo{kind: "table", keyspace: ks, table: table}
+	case inner == "all roles":
+		return listResourceInfo{kind: "all_roles"}
+	case strings.HasPrefix(inner, "role "):
+		return listResourceInfo{kind: "role", role: strings.TrimPrefix(inner, "role ")}
+	case inner == "all functions":
+		return listResourceInfo{kind: "all_functions"}
+	case strings.HasPrefix(inner, "all functions in "):
+		return listResourceInfo{kind: "all_functions", keyspace: strings.TrimPrefix(inner, "all functions in ")}
+	case strings.HasPrefix(inner, "function "):
+		ks, _, _ := strings.Cut(strings.TrimPrefix(inner, "function "), ".")
+		return listResourceInfo{kind: "function", keyspace: ks}
+	default:
+		return listResourceInfo{kind: "unknown"}
+	}
+}
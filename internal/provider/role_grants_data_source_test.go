@@ -0,0 +1,29 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseListResource(t *testing.T) {
+	tests := []struct {
+		resource string
+		expected listResourceInfo
+	}{
+		{"<all keyspaces>", listResourceInfo{kind: "all_keyspaces"}},
+		{"<keyspace ks>", listResourceInfo{kind: "keyspace", keyspace: "ks"}},
+		{"<table ks.tbl>", listResourceInfo{kind: "table", keyspace: "ks", table: "tbl"}},
+		{"<all roles>", listResourceInfo{kind: "all_roles"}},
+		{"<role admin>", listResourceInfo{kind: "role", role: "admin"}},
+		{"<all functions>", listResourceInfo{kind: "all_functions"}},
+		{"<all functions in ks>", listResourceInfo{kind: "all_functions", keyspace: "ks"}},
+		{"<function ks.fn(int)>", listResourceInfo{kind: "function", keyspace: "ks"}},
+		{"<something else>", listResourceInfo{kind: "unknown"}},
+	}
+	for _, test := range tests {
+		t.Run(test.resource, func(t *testing.T) {
+			assert.Equal(t, test.expected, parseListResource(test.resource))
+		})
+	}
+}
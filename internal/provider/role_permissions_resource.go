@@ -0,0 +1,453 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/kiwicom/terraform-provider-scylla/internal/qb"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces
+var _ tfsdk.ResourceType = rolePermissionsResourceType{}
+var _ tfsdk.Resource = rolePermissionsResource{}
+var _ tfsdk.ResourceWithUpgradeState = rolePermissionsResource{}
+var _ tfsdk.ResourceWithImportState = rolePermissionsResource{}
+var _ tfsdk.ResourceWithValidateConfig = rolePermissionsResource{}
+
+type rolePermissionsResourceType struct{}
+
+func (t rolePermissionsResourceType) GetSchema(ctx context.Context) (tfsdk.Schema, diag.Diagnostics) {
+	return tfsdk.Schema{
+		// Version 1 added the timeouts block.
+		Version: 1,
+
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Manages the complete set of direct permissions of a role on keyspaces and tables. " +
+			"Permissions of the role on keyspaces and tables that are not listed are revoked. " +
+			"Do not combine with `scylla_table_grant` or `scylla_keyspace_grant` for the same role.",
+
+		Attributes: map[string]tfsdk.Attribute{
+			"role": {
+				MarkdownDescription: "Name of the role whose permissions are managed",
+				Required:            true,
+				Type:                types.StringType,
+				Validators: []tfsdk.AttributeValidator{
+					roleNameValidator(),
+				},
+				PlanModifiers: []tfsdk.AttributePlanModifier{
+					tfsdk.RequiresReplace(),
+				},
+			},
+			"id": {
+				Computed:            true,
+				MarkdownDescription: "ID of the resource, same as `role`",
+				PlanModifiers: tfsdk.AttributePlanModifiers{
+					tfsdk.UseStateForUnknown(),
+				},
+				Type: types.StringType,
+			},
+			"permissions": {
+				MarkdownDescription: "Permissions the role has.",
+				Required:            true,
+				Attributes: tfsdk.SetNestedAttributes(map[string]tfsdk.Attribute{
+					"keyspace": {
+						MarkdownDescription: "Name of the keyspace. The permission applies to all keyspaces if not set.",
+						Optional:            true,
+						Type:                types.StringType,
+						Validators: []tfsdk.AttributeValidator{
+							keyspaceNameValidator(),
+						},
+					},
+					"table": {
+						MarkdownDescription: "Name of the table. The permission applies to the whole keyspace if not set.",
+						Optional:            true,
+						Type:                types.StringType,
+						Validators: []tfsdk.AttributeValidator{
+							tableNameValidator(),
+						},
+					},
+					"permission": {
+						MarkdownDescription: "The permission that is granted, for example `SELECT`.",
+						Required:            true,
+						Type:                types.StringType,
+					},
+				}),
+			},
+		},
+		Blocks: map[string]tfsdk.Block{
+			"timeouts": timeoutsBlock(),
+		},
+	}, nil
+}
+
+func (t rolePermissionsResourceType) NewResource(ctx context.Context, in tfsdk.Provider) (tfsdk.Resource, diag.Diagnostics) {
+	provider, diags := convertProviderType(in)
+
+	return rolePermissionsResource{
+		provider: provider,
+	}, diags
+}
+
+type rolePermissionsResourceData struct {
+	Role        types.String                            `tfsdk:"role"`
+	Id          types.String                            `tfsdk:"id"`
+	Permissions []rolePermissionsResourcePermissionData `tfsdk:"permissions"`
+
+	Timeouts []timeoutsData `tfsdk:"timeouts"`
+}
+
+type rolePermissionsResourcePermissionData struct {
+	Keyspace   types.String `tfsdk:"keyspace"`
+	Table      types.String `tfsdk:"table"`
+	Permission types.String `tfsdk:"permission"`
+}
+
+func (d *rolePermissionsResourceData) validate() diag.Diagnostics {
+	return d.validatePermissions()
+}
+
+// validatePermissions checks that every permission can be granted on its resource.
+func (d *rolePermissionsResourceData) validatePermissions() diag.Diagnostics {
+	var diags diag.Diagnostics
+	for _, p := range d.Permissions {
+		if !p.Table.IsNull() && p.Keyspace.IsNull() {
+			diags.AddAttributeError(path.Root("permissions"), "Keyspace missing",
+				fmt.Sprintf("Keyspace of table %q must be specified.", p.Table.Value))
+		}
+		if p.Table.IsNull() {
+			diags.Append(validatePermission(path.Root("permissions"), p.Permission, keyspacePermissions, "a keyspace")...)
+		} else {
+			diags.Append(validatePermission(path.Root("permissions"), p.Permission, tablePermissions, "a table")...)
+		}
+	}
+	return diags
+}
+
+// rolePermission is a single permission of a role on all keyspaces, a keyspace or a table.
+// Keyspace and table are identifiers as in the configuration, see key for the names they refer to.
+type rolePermission struct {
+	keyspace   string
+	table      string
+	permission string
+}
+
+func (p rolePermission) resource() qb.CQL {
+	switch {
+	case p.keyspace == "":
+		return "ALL KEYSPACES"
+	case p.table == "":
+		return qb.CQL(fmt.Sprintf("KEYSPACE %s", cqlName(p.keyspace)))
+	default:
+		return qb.CQL(fmt.Sprintf("%s.%s", cqlName(p.keyspace), cqlName(p.table)))
+	}
+}
+
+// key returns the permission with the names that the identifiers refer to,
+// so that permissions from the configuration can be compared with the ones listed by the server.
+func (p rolePermission) key() rolePermission {
+	return rolePermission{
+		keyspace:   qb.NormalizeName(p.keyspace),
+		table:      qb.NormalizeName(p.table),
+		permission: p.permission,
+	}
+}
+
+// permissions returns the permissions of data keyed by rolePermission.key.
+func (d *rolePermissionsResourceData) permissions() map[rolePermission]rolePermission {
+	perms := make(map[rolePermission]rolePermission, len(d.Permissions))
+	for _, p := range d.Permissions {
+		perm := p.rolePermission()
+		perms[perm.key()] = perm
+	}
+	return perms
+}
+
+// rolePermission returns the permission of the element, permissions are case-insensitive.
+func (p rolePermissionsResourcePermissionData) rolePermission() rolePermission {
+	return rolePermission{
+		keyspace:   p.Keyspace.Value,
+		table:      p.Table.Value,
+		permission: strings.ToUpper(p.Permission.Value),
+	}
+}
+
+// setPermissions replaces the permissions with perms read from the server.
+// Elements of the current permissions that refer to a permission in perms are kept as they are,
+// so that a permission configured as `select` or on keyspace `Shop` does not show a diff
+// against `SELECT` on `shop` returned by the server.
+func (d *rolePermissionsResourceData) setPermissions(perms map[rolePermission]rolePermission) {
+	elems := make(map[rolePermission]rolePermissionsResourcePermissionData, len(d.Permissions))
+	for _, p := range d.Permissions {
+		elems[p.rolePermission().key()] = p
+	}
+
+	d.Permissions = make([]rolePermissionsResourcePermissionData, 0, len(perms))
+	for key, p := range perms {
+		if elem, ok := elems[key]; ok {
+			d.Permissions = append(d.Permissions, elem)
+			continue
+		}
+		data := rolePermissionsResourcePermissionData{
+			Keyspace:   types.String{Null: true},
+			Table:      types.String{Null: true},
+			Permission: types.String{Value: p.permission},
+		}
+		if p.keyspace != "" {
+			data.Keyspace = types.String{Value: p.keyspace}
+		}
+		if p.table != "" {
+			data.Table = types.String{Value: p.table}
+		}
+		d.Permissions = append(d.Permissions, data)
+	}
+}
+
+type rolePermissionsResource struct {
+	provider provider
+}
+
+func (r rolePermissionsResource) UpgradeState(ctx context.Context) map[int64]tfsdk.ResourceStateUpgrader {
+	return map[int64]tfsdk.ResourceStateUpgrader{
+		0: upgradeStateAddTimeouts(),
+	}
+}
+
+func (r rolePermissionsResource) ValidateConfig(ctx context.Context, req tfsdk.ValidateResourceConfigRequest, resp *tfsdk.ValidateResourceConfigResponse) {
+	var permissions types.Set
+
+	diags := req.Config.GetAttribute(ctx, path.Root("permissions"), &permissions)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() || permissions.IsUnknown() {
+		return
+	}
+
+	var data rolePermissionsResourceData
+	data.Permissions = make([]rolePermissionsResourcePermissionData, 0, len(permissions.Elems))
+	diags = permissions.ElementsAs(ctx, &data.Permissions, true)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(data.validatePermissions()...)
+}
+
+func (r rolePermissionsResource) Create(ctx context.Context, req tfsdk.CreateResourceRequest, resp *tfsdk.CreateResourceResponse) {
+	var data rolePermissionsResourceData
+
+	diags := req.Config.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(data.validate()...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel, diags := withTimeout(ctx, data.Timeouts, "create")
+	defer cancel()
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.apply(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r rolePermissionsResource) Read(ctx context.Context, req tfsdk.ReadResourceRequest, resp *tfsdk.ReadResourceResponse) {
+	var data rolePermissionsResourceData
+
+	diags := req.State.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	current, err := r.list(ctx, data.Id.Value)
+	if err != nil {
+		if isNotFoundError(err) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.Append(cqlErrorDiagnostic("Query error",
+			fmt.Sprintf("Unable to list permissions of role %q.", data.Id.Value), err))
+		return
+	}
+	if len(current) == 0 {
+		// Permissions prefetched for all roles are empty for a role that does not exist,
+		// while listing permissions of the role alone fails, both mean the role was dropped.
+		_, found, err := r.provider.readRole(ctx, data.Id.Value)
+		if err != nil {
+			resp.Diagnostics.Append(cqlErrorDiagnostic("Query error",
+				fmt.Sprintf("Unable to read role %q.", data.Id.Value), err))
+			return
+		}
+		if !found {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+	}
+
+	data.Role = data.Id
+	data.setPermissions(current)
+
+	diags = resp.State.Set(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r rolePermissionsResource) Update(ctx context.Context, req tfsdk.UpdateResourceRequest, resp *tfsdk.UpdateResourceResponse) {
+	var data rolePermissionsResourceData
+
+	diags := req.Plan.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(data.validate()...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel, diags := withTimeout(ctx, data.Timeouts, "update")
+	defer cancel()
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.apply(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r rolePermissionsResource) Delete(ctx context.Context, req tfsdk.DeleteResourceRequest, resp *tfsdk.DeleteResourceResponse) {
+	var data rolePermissionsResourceData
+
+	diags := req.State.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel, diags := withTimeout(ctx, data.Timeouts, "delete")
+	defer cancel()
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	defer r.provider.roleLocks.lock(data.Id.Value)()
+	defer r.provider.permissions.invalidate(data.Id.Value)
+
+	for _, p := range data.permissions() {
+		resp.Diagnostics.Append(r.revoke(ctx, data.Id.Value, p)...)
+	}
+}
+
+func (r rolePermissionsResource) ImportState(ctx context.Context, req tfsdk.ImportResourceStateRequest, resp *tfsdk.ImportResourceStateResponse) {
+	tfsdk.ResourceImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
+
+// apply grants the permissions of data that the role does not have yet and revokes the ones that are not listed.
+func (r rolePermissionsResource) apply(ctx context.Context, data *rolePermissionsResourceData) diag.Diagnostics {
+	defer r.provider.roleLocks.lock(data.Role.Value)()
+	defer r.provider.permissions.invalidate(data.Role.Value)
+
+	// Changes are computed from fresh permissions, not the ones cached during refresh.
+	r.provider.permissions.invalidate(data.Role.Value)
+	var diags diag.Diagnostics
+	current, err := r.list(ctx, data.Role.Value)
+	if err != nil {
+		diags.Append(cqlErrorDiagnostic("Query error",
+			fmt.Sprintf("Unable to list permissions of role %q.", data.Role.Value), err))
+		return diags
+	}
+
+	desired := data.permissions()
+	for key, p := range desired {
+		if _, ok := current[key]; ok {
+			continue
+		}
+		var stmt qb.Builder
+		stmt.Appendf("GRANT %s ON %s TO %s", qb.CQL(p.permission), p.resource(), qb.QName(data.Role.Value))
+
+		result, err := r.provider.execute(ctx, stmt.String(), nil)
+		if err != nil {
+			diags.Append(cqlErrorDiagnostic("error granting", stmt.String(), err))
+			return diags
+		}
+		diags.Append(warningDiagnostics(result.Warnings)...)
+	}
+	for key, p := range current {
+		if _, ok := desired[key]; ok {
+			continue
+		}
+		diags.Append(r.revoke(ctx, data.Role.Value, p)...)
+		if diags.HasError() {
+			return diags
+		}
+	}
+
+	data.Id = data.Role
+	return diags
+}
+
+func (r rolePermissionsResource) revoke(ctx context.Context, role string, p rolePermission) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	var stmt qb.Builder
+	stmt.Appendf("REVOKE %s ON %s FROM %s", qb.CQL(p.permission), p.resource(), qb.QName(role))
+
+	result, err := r.provider.execute(ctx, stmt.String(), nil)
+	if err != nil {
+		diags.Append(cqlErrorDiagnostic("Error revoking", stmt.String(), err))
+		return diags
+	}
+	diags.Append(warningDiagnostics(result.Warnings)...)
+	return diags
+}
+
+// list returns direct permissions of the role on keyspaces and tables keyed by rolePermission.key.
+// Names listed by the server are converted to identifiers that refer to them.
+func (r rolePermissionsResource) list(ctx context.Context, role string) (map[rolePermission]rolePermission, error) {
+	rows, err := r.provider.listPermissions(ctx, role)
+	if err != nil {
+		return nil, err
+	}
+
+	perms := make(map[rolePermission]rolePermission, len(rows))
+	for _, row := range rows {
+		parsed := parseListResource(row.Resource)
+		switch parsed.kind {
+		case "all_keyspaces", "keyspace", "table":
+			perm := rolePermission{permission: row.Permission}
+			if parsed.keyspace != "" {
+				perm.keyspace = qb.Identifier(parsed.keyspace)
+			}
+			if parsed.table != "" {
+				perm.table = qb.Identifier(parsed.table)
+			}
+			perms[perm.key()] = perm
+		default:
+			// Permissions on roles and functions are not managed by this resource.
+		}
+	}
+	return perms, nil
+}
@@ -0,0 +1,186 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func rolePermissionData(keyspace, table, permission string) rolePermissionsResourcePermissionData {
+	data := rolePermissionsResourcePermissionData{
+		Keyspace:   types.String{Null: true},
+		Table:      types.String{Null: true},
+		Permission: types.String{Value: permission},
+	}
+	if keyspace != "" {
+		data.Keyspace = types.String{Value: keyspace}
+	}
+	if table != "" {
+		data.Table = types.String{Value: table}
+	}
+	return data
+}
+
+func TestRolePermissionsResourceLifecycle(t *testing.T) {
+	cluster := newFakeCluster()
+	cluster.addRole("app")
+	r := newTestResource(t, rolePermissionsResourceType{}, cluster.provider())
+
+	createResp := r.create(&rolePermissionsResourceData{
+		Role: types.String{Value: "app"},
+		Id:   types.String{Unknown: true},
+		Permissions: []rolePermissionsResourcePermissionData{
+			rolePermissionData("shop", "", "select"),
+			rolePermissionData("shop", "orders", "MODIFY"),
+		},
+	})
+	require.False(t, createResp.Diagnostics.HasError(), "%v", createResp.Diagnostics)
+	assert.True(t, cluster.hasPermission("app", "<keyspace shop>", "SELECT"))
+	assert.True(t, cluster.hasPermission("app", "<table shop.orders>", "MODIFY"))
+
+	// The permission keeps the case of the configuration, which the server does not preserve.
+	readResp := r.read(createResp.State)
+	require.False(t, readResp.Diagnostics.HasError(), "%v", readResp.Diagnostics)
+	var read rolePermissionsResourceData
+	getTestState(t, readResp.State, &read)
+	assert.Equal(t, "app", read.Role.Value)
+	assert.ElementsMatch(t, []rolePermissionsResourcePermissionData{
+		rolePermissionData("shop", "", "select"),
+		rolePermissionData("shop", "orders", "MODIFY"),
+	}, read.Permissions)
+
+	// Applying the same configuration again does not grant anything.
+	executed := len(cluster.executed)
+	updateResp := r.update(readResp.State, &read)
+	require.False(t, updateResp.Diagnostics.HasError(), "%v", updateResp.Diagnostics)
+	for _, stmt := range cluster.executed[executed:] {
+		assert.NotRegexp(t, "^(GRANT|REVOKE) ", stmt)
+	}
+
+	deleteResp := r.delete(readResp.State)
+	require.False(t, deleteResp.Diagnostics.HasError(), "%v", deleteResp.Diagnostics)
+	assert.False(t, cluster.hasPermission("app", "<keyspace shop>", "SELECT"))
+	assert.False(t, cluster.hasPermission("app", "<table shop.orders>", "MODIFY"))
+}
+
+func TestRolePermissionsResourceReadDrift(t *testing.T) {
+	cluster := newFakeCluster()
+	cluster.addRole("app")
+	for _, stmt := range []string{
+		`GRANT SELECT ON KEYSPACE "shop" TO "app"`,
+		`GRANT MODIFY ON ALL KEYSPACES TO "app"`,
+	} {
+		_, err := cluster.Execute(context.Background(), 0, stmt, nil)
+		require.NoError(t, err)
+	}
+	r := newTestResource(t, rolePermissionsResourceType{}, cluster.provider())
+
+	// SELECT on shop.orders was revoked and MODIFY on all keyspaces granted outside of Terraform.
+	resp := r.read(r.state(&rolePermissionsResourceData{
+		Role: types.String{Value: "app"},
+		Id:   types.String{Value: "app"},
+		Permissions: []rolePermissionsResourcePermissionData{
+			rolePermissionData("shop", "", "select"),
+			rolePermissionData("shop", "orders", "SELECT"),
+		},
+	}))
+	require.False(t, resp.Diagnostics.HasError(), "%v", resp.Diagnostics)
+	var read rolePermissionsResourceData
+	getTestState(t, resp.State, &read)
+	assert.ElementsMatch(t, []rolePermissionsResourcePermissionData{
+		rolePermissionData("shop", "", "select"),
+		rolePermissionData("", "", "MODIFY"),
+	}, read.Permissions)
+}
+
+func TestRolePermissionsResourceReadMissingRole(t *testing.T) {
+	for _, prefetch := range []bool{false, true} {
+		cluster := newFakeCluster()
+		cluster.addRole("other")
+		_, err := cluster.Execute(context.Background(), 0, `GRANT SELECT ON KEYSPACE "shop" TO "other"`, nil)
+		require.NoError(t, err)
+		p := cluster.provider()
+		p.prefetchPermissions = prefetch
+		r := newTestResource(t, rolePermissionsResourceType{}, p)
+
+		// The role was dropped outside of Terraform.
+		resp := r.read(r.state(&rolePermissionsResourceData{
+			Role: types.String{Value: "app"},
+			Id:   types.String{Value: "app"},
+			Permissions: []rolePermissionsResourcePermissionData{
+				rolePermissionData("shop", "", "SELECT"),
+			},
+		}))
+		require.False(t, resp.Diagnostics.HasError(), "prefetch=%v: %v", prefetch, resp.Diagnostics)
+		assert.True(t, resp.State.Raw.IsNull(), "prefetch=%v", prefetch)
+	}
+}
+
+func TestRolePermissionsResourceReadNoPermissions(t *testing.T) {
+	cluster := newFakeCluster()
+	cluster.addRole("app")
+	r := newTestResource(t, rolePermissionsResourceType{}, cluster.provider())
+
+	// The role exists but all of its permissions were revoked, which is drift, not a removed resource.
+	resp := r.read(r.state(&rolePermissionsResourceData{
+		Role: types.String{Value: "app"},
+		Id:   types.String{Value: "app"},
+		Permissions: []rolePermissionsResourcePermissionData{
+			rolePermissionData("shop", "", "SELECT"),
+		},
+	}))
+	require.False(t, resp.Diagnostics.HasError(), "%v", resp.Diagnostics)
+	require.False(t, resp.State.Raw.IsNull())
+	var read rolePermissionsResourceData
+	getTestState(t, resp.State, &read)
+	assert.Empty(t, read.Permissions)
+}
+
+func TestRolePermissionsResourceNames(t *testing.T) {
+	cluster := newFakeCluster()
+	cluster.addRole("app")
+	_, err := cluster.Execute(context.Background(), 0, `GRANT SELECT ON "Other"."Events" TO "app"`, nil)
+	require.NoError(t, err)
+	r := newTestResource(t, rolePermissionsResourceType{}, cluster.provider())
+
+	createResp := r.create(&rolePermissionsResourceData{
+		Role: types.String{Value: "app"},
+		Id:   types.String{Unknown: true},
+		Permissions: []rolePermissionsResourcePermissionData{
+			rolePermissionData(`"MyKs"`, "", "SELECT"),
+			rolePermissionData("Shop", "Orders", "SELECT"),
+			rolePermissionData(`"Other"`, `"Events"`, "SELECT"),
+		},
+	})
+	require.False(t, createResp.Diagnostics.HasError(), "%v", createResp.Diagnostics)
+	assert.True(t, cluster.hasPermission("app", "<keyspace MyKs>", "SELECT"))
+	assert.True(t, cluster.hasPermission("app", "<table shop.orders>", "SELECT"))
+	assert.Contains(t, cluster.executed[1:], `GRANT SELECT ON KEYSPACE "MyKs" TO "app"`)
+	assert.NotContains(t, cluster.executed[1:], `GRANT SELECT ON "Other"."Events" TO "app"`, "already granted")
+
+	// Identifiers of the configuration are kept, permissions granted outside of Terraform
+	// are read as identifiers that refer to them.
+	_, err = cluster.Execute(context.Background(), 0, `GRANT MODIFY ON KEYSPACE "Logs" TO "app"`, nil)
+	require.NoError(t, err)
+	readResp := r.read(createResp.State)
+	require.False(t, readResp.Diagnostics.HasError(), "%v", readResp.Diagnostics)
+	var read rolePermissionsResourceData
+	getTestState(t, readResp.State, &read)
+	assert.ElementsMatch(t, []rolePermissionsResourcePermissionData{
+		rolePermissionData(`"MyKs"`, "", "SELECT"),
+		rolePermissionData("Shop", "Orders", "SELECT"),
+		rolePermissionData(`"Other"`, `"Events"`, "SELECT"),
+		rolePermissionData(`"Logs"`, "", "MODIFY"),
+	}, read.Permissions)
+
+	// Removing the permission from the configuration revokes it using the identifier.
+	read.Permissions = read.Permissions[:0]
+	updateResp := r.update(readResp.State, &read)
+	require.False(t, updateResp.Diagnostics.HasError(), "%v", updateResp.Diagnostics)
+	assert.Contains(t, cluster.executed, `REVOKE MODIFY ON KEYSPACE "Logs" FROM "app"`)
+	assert.False(t, cluster.hasPermission("app", "<keyspace Logs>", "MODIFY"))
+	assert.False(t, cluster.hasPermission("app", "<keyspace MyKs>", "SELECT"))
+}
@@ -5,26 +5,31 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
-	"github.com/scylladb/scylla-go-driver/frame"
 	"golang.org/x/crypto/bcrypt"
 
 	"github.com/kiwicom/terraform-provider-scylla/internal/qb"
+	"github.com/kiwicom/terraform-provider-scylla/internal/scan"
 )
 
 // Ensure provider defined types fully satisfy framework interfaces
 var _ tfsdk.ResourceType = roleResourceType{}
 var _ tfsdk.Resource = roleResource{}
+var _ tfsdk.ResourceWithUpgradeState = roleResource{}
 var _ tfsdk.ResourceWithImportState = roleResource{}
 
 type roleResourceType struct{}
 
 func (t roleResourceType) GetSchema(ctx context.Context) (tfsdk.Schema, diag.Diagnostics) {
 	return tfsdk.Schema{
+		// Version 1 added the timeouts block.
+		Version: 1,
+
 		// This description is used by the documentation generator and the language server.
 		MarkdownDescription: "Scylla role",
 
@@ -33,6 +38,9 @@ func (t roleResourceType) GetSchema(ctx context.Context) (tfsdk.Schema, diag.Dia
 				MarkdownDescription: "Name of the role",
 				Required:            true,
 				Type:                types.StringType,
+				Validators: []tfsdk.AttributeValidator{
+					roleNameValidator(),
+				},
 				PlanModifiers: []tfsdk.AttributePlanModifier{
 					tfsdk.RequiresReplace(),
 				},
@@ -69,8 +77,51 @@ func (t roleResourceType) GetSchema(ctx context.Context) (tfsdk.Schema, diag.Dia
 				MarkdownDescription: "Name of the service level attached to this role.",
 				Optional:            true,
 				Type:                types.StringType,
+				Validators: []tfsdk.AttributeValidator{
+					serviceLevelNameValidator(),
+				},
+			},
+			"member_of": {
+				MarkdownDescription: "Names of the roles granted to this role. Memberships are not managed if not set, so that they can be managed elsewhere.",
+				Optional:            true,
+				Type:                types.SetType{ElemType: types.StringType},
+			},
+			"allow_self_destroy": {
+				MarkdownDescription: "Allow dropping the role even if it is the role the provider is connected as. Defaults to false, which makes destroy of that role fail, as it would lock the provider out of the cluster.",
+				Optional:            true,
+				Type:                types.BoolType,
+			},
+			"deletion_protection": {
+				MarkdownDescription: "Make destroy of the role fail. Set to false and apply before the role can be destroyed. Defaults to false.",
+				Optional:            true,
+				Type:                types.BoolType,
+			},
+			"drop_on_destroy": {
+				MarkdownDescription: "Drop the role when the resource is destroyed. If false, destroy only removes the role from the Terraform state. Defaults to true.",
+				Optional:            true,
+				Type:                types.BoolType,
+			},
+			"adopt_existing": {
+				MarkdownDescription: "Adopt the role if it exists already when the resource is created, instead of failing. " +
+					"Its login, superuser, password, service level and memberships are then aligned with the configuration. " +
+					"Useful when bootstrapping clusters where the role may have been created by other tooling. " +
+					"Has no effect once the resource is created. Defaults to false.",
+				Optional: true,
+				Type:     types.BoolType,
+			},
+			"salted_hash": {
+				MarkdownDescription: "Salted hash of the password as stored by the server. A change of the hash reveals that the password was changed outside of Terraform.",
+				Computed:            true,
+				Sensitive:           true,
+				Type:                types.StringType,
+				PlanModifiers: tfsdk.AttributePlanModifiers{
+					saltedHashPlanModifier{},
+				},
 			},
 		},
+		Blocks: map[string]tfsdk.Block{
+			"timeouts": timeoutsBlock(),
+		},
 	}, nil
 }
 
@@ -83,18 +134,60 @@ func (t roleResourceType) NewResource(ctx context.Context, in tfsdk.Provider) (t
 }
 
 type roleResourceData struct {
-	Name         types.String `tfsdk:"name"`
-	Id           types.String `tfsdk:"id"`
-	Login        types.Bool   `tfsdk:"login"`
-	Superuser    types.Bool   `tfsdk:"superuser"`
-	Password     types.String `tfsdk:"password"`
-	ServiceLevel types.String `tfsdk:"service_level"`
+	Name               types.String `tfsdk:"name"`
+	Id                 types.String `tfsdk:"id"`
+	Login              types.Bool   `tfsdk:"login"`
+	Superuser          types.Bool   `tfsdk:"superuser"`
+	Password           types.String `tfsdk:"password"`
+	ServiceLevel       types.String `tfsdk:"service_level"`
+	MemberOf           types.Set    `tfsdk:"member_of"`
+	AllowSelfDestroy   types.Bool   `tfsdk:"allow_self_destroy"`
+	DeletionProtection types.Bool   `tfsdk:"deletion_protection"`
+	DropOnDestroy      types.Bool   `tfsdk:"drop_on_destroy"`
+	AdoptExisting      types.Bool   `tfsdk:"adopt_existing"`
+	SaltedHash         types.String `tfsdk:"salted_hash"`
+
+	Timeouts []timeoutsData `tfsdk:"timeouts"`
+}
+
+// saltedHashPlanModifier keeps the salted hash from the state unless the password is changed.
+type saltedHashPlanModifier struct{}
+
+func (m saltedHashPlanModifier) Description(ctx context.Context) string {
+	return "The value is unknown when the password changes, otherwise it is kept from the state."
+}
+
+func (m saltedHashPlanModifier) MarkdownDescription(ctx context.Context) string {
+	return m.Description(ctx)
+}
+
+func (m saltedHashPlanModifier) Modify(ctx context.Context, req tfsdk.ModifyAttributePlanRequest, resp *tfsdk.ModifyAttributePlanResponse) {
+	if req.State.Raw.IsNull() || req.AttributeState == nil || req.AttributeState.IsNull() {
+		return
+	}
+
+	var planPassword, statePassword types.String
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("password"), &planPassword)...)
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("password"), &statePassword)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if planPassword.Equal(statePassword) {
+		resp.AttributePlan = req.AttributeState
+	}
 }
 
 type roleResource struct {
 	provider provider
 }
 
+func (r roleResource) UpgradeState(ctx context.Context) map[int64]tfsdk.ResourceStateUpgrader {
+	return map[int64]tfsdk.ResourceStateUpgrader{
+		0: upgradeStateAddTimeouts(),
+	}
+}
+
 func (r roleResource) Create(ctx context.Context, req tfsdk.CreateResourceRequest, resp *tfsdk.CreateResourceResponse) {
 	var data roleResourceData
 
@@ -105,19 +198,71 @@ func (r roleResource) Create(ctx context.Context, req tfsdk.CreateResourceReques
 		return
 	}
 
+	ctx, cancel, diags := withTimeout(ctx, data.Timeouts, "create")
+	defer cancel()
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	data.Id = data.Name
 
-	var stmt qb.Builder
-	stmt.Appendf("CREATE ROLE %s", qb.QName(data.Name.Value))
-	stmt.Appendf(" WITH LOGIN = %s", qb.Bool(data.Login.Value))
-	stmt.Appendf(" AND SUPERUSER = %s", qb.Bool(data.Superuser.Value))
+	defer r.provider.roleLocks.lock(data.Name.Value)()
+
+	var options qb.OptionsBuilder
+	options.Set("LOGIN", qb.Bool(data.Login.Value))
+	options.Set("SUPERUSER", qb.Bool(data.Superuser.Value))
 	if !data.Password.IsNull() {
-		stmt.Appendf(" AND PASSWORD = %s", qb.String(data.Password.Value))
+		options.Set("PASSWORD", qb.String(data.Password.Value))
+	}
+
+	var stmt qb.Builder
+	stmt.Append("CREATE ROLE ")
+	if data.AdoptExisting.Value {
+		stmt.Append("IF NOT EXISTS ")
+	}
+	stmt.Append(qb.QName(data.Name.Value))
+	stmt.Append(options.CQL())
+
+	result, err := r.provider.execute(ctx, stmt.String(), nil)
+	if err != nil {
+		resp.Diagnostics.Append(cqlErrorDiagnostic("error creating role",
+			fmt.Sprintf("Unable to create role %q.", data.Name.Value), err))
+		return
+	}
+	resp.Diagnostics.Append(warningDiagnostics(result.Warnings)...)
+
+	// Memberships of an adopted role that are not configured are revoked by updateMemberOf.
+	existingMemberOf := types.Set{ElemType: types.StringType, Null: true}
+	if data.AdoptExisting.Value {
+		// The role may have existed with other attributes, align them with the configuration.
+		var alterStmt qb.Builder
+		alterStmt.Appendf("ALTER ROLE %s", qb.QName(data.Name.Value))
+		alterStmt.Append(options.CQL())
+		result, err := r.provider.execute(ctx, alterStmt.String(), nil)
+		if err != nil {
+			resp.Diagnostics.Append(cqlErrorDiagnostic("error adopting role",
+				fmt.Sprintf("Unable to alter existing role %q.", data.Name.Value), err))
+			return
+		}
+		resp.Diagnostics.Append(warningDiagnostics(result.Warnings)...)
+
+		info, _, err := r.provider.readRole(ctx, data.Name.Value)
+		if err != nil {
+			resp.Diagnostics.Append(cqlErrorDiagnostic("Query error",
+				fmt.Sprintf("Unable to read memberships of adopted role %q.", data.Name.Value), err))
+			return
+		}
+		existingMemberOf.Null = false
+		for _, parent := range info.memberOf {
+			existingMemberOf.Elems = append(existingMemberOf.Elems, types.String{Value: parent})
+		}
 	}
 
-	_, err := r.provider.execute(ctx, stmt.String(), nil)
+	data.SaltedHash, err = r.readSaltedHash(ctx, data.Name.Value)
 	if err != nil {
-		resp.Diagnostics.AddError("error creating role", err.Error())
+		resp.Diagnostics.AddError("Query error", fmt.Sprintf("Unable to read role salted_hash: %s", err))
 		return
 	}
 
@@ -126,26 +271,39 @@ func (r roleResource) Create(ctx context.Context, req tfsdk.CreateResourceReques
 	// for more information
 	tflog.Trace(ctx, "created role")
 
-	dataWithoutSL := data
-	dataWithoutSL.ServiceLevel = types.String{Null: true}
+	partial := data
+	partial.ServiceLevel = types.String{Null: true}
+	partial.MemberOf = types.Set{ElemType: types.StringType, Null: true}
 
-	diags = resp.State.Set(ctx, &dataWithoutSL)
+	diags = resp.State.Set(ctx, &partial)
 	resp.Diagnostics.Append(diags...)
 
 	if diags.HasError() {
 		return
 	}
 
-	if data.ServiceLevel.Value == "" {
-		return
+	if data.ServiceLevel.Value != "" {
+		var slStmt qb.Builder
+		slStmt.Appendf("ATTACH SERVICE LEVEL %s TO %s",
+			qb.QName(data.ServiceLevel.Value), qb.QName(data.Name.Value))
+		result, err = r.provider.execute(ctx, slStmt.String(), nil)
+		if err != nil {
+			resp.Diagnostics.Append(cqlErrorDiagnostic("Error attaching service level", slStmt.String(), err))
+			return
+		}
+		resp.Diagnostics.Append(warningDiagnostics(result.Warnings)...)
+
+		partial.ServiceLevel = data.ServiceLevel
+		diags = resp.State.Set(ctx, &partial)
+		resp.Diagnostics.Append(diags...)
+
+		if diags.HasError() {
+			return
+		}
 	}
 
-	var slStmt qb.Builder
-	slStmt.Appendf("ATTACH SERVICE LEVEL %s TO %s",
-		qb.QName(data.ServiceLevel.Value), qb.QName(data.Name.Value))
-	_, err = r.provider.execute(ctx, slStmt.String(), nil)
-	if err != nil {
-		resp.Diagnostics.AddError("Error attaching service level", err.Error())
+	resp.Diagnostics.Append(r.updateMemberOf(ctx, data.Name.Value, existingMemberOf, data.MemberOf)...)
+	if resp.Diagnostics.HasError() {
 		return
 	}
 
@@ -163,51 +321,51 @@ func (r roleResource) Read(ctx context.Context, req tfsdk.ReadResourceRequest, r
 		return
 	}
 
-	cqlName, err := frame.CqlFromASCII(data.Id.Value)
-	if err != nil {
-		resp.Diagnostics.AddError("Cannot convert role name", err.Error())
-		return
-	}
+	found, diags := r.readData(ctx, &data)
+	resp.Diagnostics.Append(diags...)
 
-	result, err := r.provider.execute(ctx, "SELECT can_login, is_superuser, salted_hash FROM system_auth.roles WHERE role = ?",
-		[]frame.CqlValue{cqlName})
-	if err != nil {
-		resp.Diagnostics.AddError("Query error", fmt.Sprintf("Unable to read role info: %s", err))
+	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	if len(result.Rows) == 0 {
+	if !found {
 		resp.State.RemoveResource(ctx)
 		return
 	}
 
-	canLogin, err := result.Rows[0][0].AsBoolean()
-	if err != nil {
-		resp.Diagnostics.AddError("Query result error",
-			fmt.Sprintf("Unable to read role can_login: %s", err))
-		return
-	}
-	isSuperuser, err := result.Rows[0][1].AsBoolean()
+	diags = resp.State.Set(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+}
+
+// readData refreshes data from the cluster. The returned bool is false if the role does not exist.
+// Memberships are read only if member_of is set, as they are not managed otherwise.
+func (r roleResource) readData(ctx context.Context, data *roleResourceData) (bool, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	info, found, err := r.provider.readRole(ctx, data.Id.Value)
 	if err != nil {
-		resp.Diagnostics.AddError("Query result error",
-			fmt.Sprintf("Unable to read role is_superuser: %s", err))
-		return
+		diags.Append(cqlErrorDiagnostic("Query error", "Unable to read role info.", err))
+		return false, diags
 	}
-	saltedHash, err := result.Rows[0][2].AsText()
-	if err != nil {
-		resp.Diagnostics.AddError("Query result error",
-			fmt.Sprintf("Unable to read role salted_hash: %s", err))
-		return
+
+	if !found {
+		return false, diags
 	}
 
-	data.Login.Null = false
-	data.Login.Unknown = false
-	data.Login.Value = canLogin
-	data.Superuser.Null = false
-	data.Superuser.Unknown = false
-	data.Superuser.Value = isSuperuser
+	data.Login = types.Bool{Value: info.login}
+	data.Superuser = types.Bool{Value: info.superuser}
+
+	// The hash is salted, so it changes with every password change even if the same password is set again.
+	saltedHash := info.saltedHash
+	hashChanged := info.hasSaltedHash && !data.SaltedHash.IsNull() && !data.SaltedHash.IsUnknown() &&
+		data.SaltedHash.Value != saltedHash
+	if hashChanged && data.Password.IsNull() {
+		diags.AddAttributeWarning(path.Root("salted_hash"), "Password changed outside of Terraform",
+			fmt.Sprintf("The password of role %q was changed outside of Terraform. "+
+				"Set password to manage it, or ignore changes of salted_hash to accept the change.", data.Id.Value))
+	}
 
-	if !data.Password.IsNull() {
+	if info.hasSaltedHash && !data.Password.IsNull() &&
+		(hashChanged || data.SaltedHash.IsNull() || data.SaltedHash.IsUnknown()) {
 		// https://github.com/scylladb/scylladb/blob/c51a41a8850ac6f595b920b65860c170b5f215b5/auth/passwords.cc
 		switch {
 		case strings.HasPrefix(saltedHash, "$2a$"), strings.HasPrefix(saltedHash, "$2y$"):
@@ -223,35 +381,39 @@ func (r roleResource) Read(ctx context.Context, req tfsdk.ReadResourceRequest, r
 			// Use password from state.
 		}
 	}
+	if info.hasSaltedHash {
+		data.SaltedHash = saltedHashValue(saltedHash)
+	}
+
+	if !data.MemberOf.IsNull() {
+		data.MemberOf = types.Set{ElemType: types.StringType, Elems: []attr.Value{}}
+		for _, role := range info.memberOf {
+			data.MemberOf.Elems = append(data.MemberOf.Elems, types.String{Value: role})
+		}
+	}
 
 	var slStmt qb.Builder
 	slStmt.Appendf("LIST ATTACHED SERVICE LEVEL OF %s", qb.QName(data.Name.Value))
-	slResult, err := r.provider.execute(ctx, slStmt.String(), nil)
+	slResult, err := r.provider.executeAuthRead(ctx, slStmt.String(), nil)
 	if err != nil {
-		resp.Diagnostics.AddError("Query error",
+		diags.AddError("Query error",
 			fmt.Sprintf("Unable to read attached service level:\n%s\n%s", slStmt.String(), err))
-		return
+		return false, diags
 	}
 
 	data.ServiceLevel = types.String{Null: true}
 	if len(slResult.Rows) > 0 {
-		colSL, err := findColumn("service_level", slResult.ColSpec)
-		if err != nil {
-			resp.Diagnostics.AddError("Query error",
-				fmt.Sprintf("Unable to read attached service level: %s", err))
-			return
+		var row struct {
+			ServiceLevel string `cql:"service_level"`
 		}
-		sl, err := slResult.Rows[0][colSL].AsText()
-		if err != nil {
-			resp.Diagnostics.AddError("Query error",
+		if err := scan.Row(slResult, 0, &row); err != nil {
+			diags.AddError("Query error",
 				fmt.Sprintf("Unable to read attached service level: %s", err))
-			return
+			return false, diags
 		}
-		data.ServiceLevel = types.String{Value: sl}
+		data.ServiceLevel = types.String{Value: row.ServiceLevel}
 	}
-
-	diags = resp.State.Set(ctx, &data)
-	resp.Diagnostics.Append(diags...)
+	return true, diags
 }
 
 func (r roleResource) Update(ctx context.Context, req tfsdk.UpdateResourceRequest, resp *tfsdk.UpdateResourceResponse) {
@@ -271,25 +433,49 @@ func (r roleResource) Update(ctx context.Context, req tfsdk.UpdateResourceReques
 		return
 	}
 
-	var stmt qb.Builder
-	stmt.Appendf("ALTER ROLE %s", qb.QName(plan.Id.Value))
+	ctx, cancel, diags := withTimeout(ctx, plan.Timeouts, "update")
+	defer cancel()
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	defer r.provider.roleLocks.lock(plan.Id.Value)()
+
+	var options qb.OptionsBuilder
 	if !plan.Login.Equal(state.Login) {
-		stmt.Once("with", " WITH ", " AND ")
-		stmt.Appendf("LOGIN = %s", qb.Bool(plan.Login.Value))
+		options.Set("LOGIN", qb.Bool(plan.Login.Value))
 	}
 	if !plan.Superuser.Equal(state.Superuser) {
-		stmt.Once("with", " WITH ", " AND ")
-		stmt.Appendf("SUPERUSER = %s", qb.Bool(plan.Superuser.Value))
+		options.Set("SUPERUSER", qb.Bool(plan.Superuser.Value))
 	}
 	if !plan.Password.Equal(state.Password) && !plan.Password.IsNull() {
-		stmt.Once("with", " WITH ", " AND ")
-		stmt.Appendf("PASSWORD = %s", qb.String(plan.Password.Value))
+		options.Set("PASSWORD", qb.String(plan.Password.Value))
 	}
 
-	_, err := r.provider.execute(ctx, stmt.String(), nil)
-	if err != nil {
-		resp.Diagnostics.AddError("error altering role", err.Error())
-		return
+	// Attributes that only affect the provider, such as deletion_protection, change no option.
+	if options.Len() > 0 {
+		var stmt qb.Builder
+		stmt.Appendf("ALTER ROLE %s", qb.QName(plan.Id.Value))
+		stmt.Append(options.CQL())
+
+		result, err := r.provider.execute(ctx, stmt.String(), nil)
+		if err != nil {
+			resp.Diagnostics.Append(cqlErrorDiagnostic("error altering role",
+				fmt.Sprintf("Unable to alter role %q.", plan.Id.Value), err))
+			return
+		}
+		resp.Diagnostics.Append(warningDiagnostics(result.Warnings)...)
+	}
+
+	if plan.SaltedHash.IsUnknown() {
+		var err error
+		plan.SaltedHash, err = r.readSaltedHash(ctx, plan.Id.Value)
+		if err != nil {
+			resp.Diagnostics.AddError("Query error", fmt.Sprintf("Unable to read role salted_hash: %s", err))
+			return
+		}
 	}
 
 	if !plan.ServiceLevel.Equal(state.ServiceLevel) {
@@ -301,11 +487,18 @@ func (r roleResource) Update(ctx context.Context, req tfsdk.UpdateResourceReques
 			slStmt.Appendf("DETACH SERVICE LEVEL FROM %s", qb.QName(plan.Name.Value))
 		}
 
-		_, err = r.provider.execute(ctx, slStmt.String(), nil)
+		result, err := r.provider.execute(ctx, slStmt.String(), nil)
 		if err != nil {
-			resp.Diagnostics.AddError("Error updating service level attachment", err.Error())
+			resp.Diagnostics.Append(cqlErrorDiagnostic("Error updating service level attachment",
+				slStmt.String(), err))
 			return
 		}
+		resp.Diagnostics.Append(warningDiagnostics(result.Warnings)...)
+	}
+
+	resp.Diagnostics.Append(r.updateMemberOf(ctx, plan.Name.Value, state.MemberOf, plan.MemberOf)...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
 
 	diags = resp.State.Set(ctx, plan)
@@ -322,16 +515,155 @@ func (r roleResource) Delete(ctx context.Context, req tfsdk.DeleteResourceReques
 		return
 	}
 
+	ctx, cancel, diags := withTimeout(ctx, data.Timeouts, "delete")
+	defer cancel()
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !data.DropOnDestroy.IsNull() && !data.DropOnDestroy.Value {
+		tflog.Info(ctx, "Removing role from state without dropping it", map[string]interface{}{"role": data.Id.Value})
+		return
+	}
+
+	if data.DeletionProtection.Value {
+		resp.Diagnostics.AddError("Role is protected from deletion",
+			fmt.Sprintf("Role %q has deletion_protection enabled, set it to false and apply before destroying the role.",
+				data.Id.Value))
+		return
+	}
+
+	if data.Id.Value == r.provider.username && !data.AllowSelfDestroy.Value {
+		resp.Diagnostics.AddError("Refusing to drop the connected role",
+			fmt.Sprintf("Role %q is the role the provider is connected as, dropping it would lock the provider out of the cluster. "+
+				"Set allow_self_destroy to true to drop it anyway.", data.Id.Value))
+		return
+	}
+
+	defer r.provider.roleLocks.lock(data.Id.Value)()
+	defer r.provider.permissions.invalidate(data.Id.Value)
+
 	var stmt qb.Builder
 	stmt.Appendf("DROP ROLE %s", qb.QName(data.Id.Value))
 
-	_, err := r.provider.execute(ctx, stmt.String(), nil)
+	result, err := r.provider.execute(ctx, stmt.String(), nil)
 	if err != nil {
-		resp.Diagnostics.AddError("error dropping role", err.Error())
+		resp.Diagnostics.Append(cqlErrorDiagnostic("error dropping role", stmt.String(), err))
 		return
 	}
+	resp.Diagnostics.Append(warningDiagnostics(result.Warnings)...)
+}
+
+// updateMemberOf grants the role the roles that are in plan but not in state and revokes those in state but not in plan.
+// Memberships are left untouched if plan is null.
+func (r roleResource) updateMemberOf(ctx context.Context, role string, state, plan types.Set) diag.Diagnostics {
+	var diags diag.Diagnostics
+	if plan.IsNull() || plan.IsUnknown() {
+		return diags
+	}
+
+	stateRoles := make(map[string]struct{}, len(state.Elems))
+	for _, elem := range state.Elems {
+		stateRoles[elem.(types.String).Value] = struct{}{}
+	}
+	planRoles := make(map[string]struct{}, len(plan.Elems))
+	for _, elem := range plan.Elems {
+		planRoles[elem.(types.String).Value] = struct{}{}
+	}
+
+	for _, elem := range plan.Elems {
+		parent := elem.(types.String).Value
+		if _, ok := stateRoles[parent]; ok {
+			continue
+		}
+		var stmt qb.Builder
+		stmt.Appendf("GRANT %s TO %s", qb.QName(parent), qb.QName(role))
+		result, err := r.provider.execute(ctx, stmt.String(), nil)
+		if err != nil {
+			diags.AddAttributeError(path.Root("member_of"), "Error granting role",
+				fmt.Sprintf("Unable to grant role %q to %q: %s", parent, role, err))
+			return diags
+		}
+		diags.Append(warningDiagnostics(result.Warnings)...)
+	}
+
+	for _, elem := range state.Elems {
+		parent := elem.(types.String).Value
+		if _, ok := planRoles[parent]; ok {
+			continue
+		}
+		var stmt qb.Builder
+		stmt.Appendf("REVOKE %s FROM %s", qb.QName(parent), qb.QName(role))
+		result, err := r.provider.execute(ctx, stmt.String(), nil)
+		if err != nil {
+			diags.AddAttributeError(path.Root("member_of"), "Error revoking role",
+				fmt.Sprintf("Unable to revoke role %q from %q: %s", parent, role, err))
+			return diags
+		}
+		diags.Append(warningDiagnostics(result.Warnings)...)
+	}
+	return diags
+}
+
+// readSaltedHash returns the salted hash of the password of the role, null if the hash is unavailable.
+func (r roleResource) readSaltedHash(ctx context.Context, name string) (types.String, error) {
+	info, found, err := r.provider.readRole(ctx, name)
+	if err != nil {
+		return types.String{}, err
+	}
+	if !found {
+		return types.String{}, fmt.Errorf("role %s does not exist", name)
+	}
+	if !info.hasSaltedHash {
+		return types.String{Null: true}, nil
+	}
+	return saltedHashValue(info.saltedHash), nil
+}
+
+// saltedHashValue converts the salted hash to a value, roles without a password have no hash.
+func saltedHashValue(saltedHash string) types.String {
+	if saltedHash == "" {
+		return types.String{Null: true}
+	}
+	return types.String{Value: saltedHash}
 }
 
+// ImportState imports the role by its name. The whole state is read, including name,
+// so that the first plan after import is empty, and roles that do not exist are rejected.
+// Memberships are imported only if the role has some, otherwise member_of is left unmanaged.
+// The password cannot be read back, it is set by the first apply if it is configured.
 func (r roleResource) ImportState(ctx context.Context, req tfsdk.ImportResourceStateRequest, resp *tfsdk.ImportResourceStateResponse) {
-	tfsdk.ResourceImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	data := roleResourceData{
+		Name:               types.String{Value: req.ID},
+		Id:                 types.String{Value: req.ID},
+		Password:           types.String{Null: true},
+		ServiceLevel:       types.String{Null: true},
+		MemberOf:           types.Set{ElemType: types.StringType, Elems: []attr.Value{}},
+		AllowSelfDestroy:   types.Bool{Null: true},
+		DeletionProtection: types.Bool{Null: true},
+		DropOnDestroy:      types.Bool{Null: true},
+		AdoptExisting:      types.Bool{Null: true},
+		SaltedHash:         types.String{Null: true},
+	}
+
+	found, diags := r.readData(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !found {
+		resp.Diagnostics.AddError("Role not found", fmt.Sprintf("Cannot import role %q, it does not exist.", req.ID))
+		return
+	}
+
+	if len(data.MemberOf.Elems) == 0 {
+		data.MemberOf = types.Set{ElemType: types.StringType, Null: true}
+	}
+
+	diags = resp.State.Set(ctx, &data)
+	resp.Diagnostics.Append(diags...)
 }
@@ -1,10 +1,19 @@
 package provider
 
 import (
+	"context"
 	"fmt"
 	"testing"
 
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/bcrypt"
 )
 
 func TestAccRoleResource(t *testing.T) {
@@ -24,11 +33,6 @@ func TestAccRoleResource(t *testing.T) {
 				ResourceName:      "scylla_role.test",
 				ImportState:       true,
 				ImportStateVerify: true,
-				// This is not normally necessary, but is here because this
-				// example code does not have an actual upstream service.
-				// Once the Read method is able to refresh information from
-				// the upstream service, this can be removed.
-				ImportStateVerifyIgnore: []string{"name"},
 			},
 			// Update and Read testing
 			{
@@ -49,3 +53,265 @@ resource "scylla_role" "test" {
 }
 `, name)
 }
+
+func newTestRoleData(name string) roleResourceData {
+	return roleResourceData{
+		Name:               types.String{Value: name},
+		Id:                 types.String{Unknown: true},
+		Login:              types.Bool{Value: true},
+		Superuser:          types.Bool{Value: false},
+		Password:           types.String{Null: true},
+		ServiceLevel:       types.String{Null: true},
+		MemberOf:           types.Set{ElemType: types.StringType, Null: true},
+		AllowSelfDestroy:   types.Bool{Null: true},
+		DeletionProtection: types.Bool{Null: true},
+		DropOnDestroy:      types.Bool{Null: true},
+		AdoptExisting:      types.Bool{Null: true},
+		SaltedHash:         types.String{Unknown: true},
+	}
+}
+
+func TestRoleResourceLifecycle(t *testing.T) {
+	cluster := newFakeCluster()
+	cluster.addRole("parent")
+	r := newTestResource(t, roleResourceType{}, cluster.provider())
+
+	config := newTestRoleData("app")
+	config.Password = types.String{Value: "secret"}
+	config.MemberOf = types.Set{ElemType: types.StringType, Elems: []attr.Value{types.String{Value: "parent"}}}
+
+	createResp := r.create(&config)
+	require.False(t, createResp.Diagnostics.HasError(), "%v", createResp.Diagnostics)
+
+	role := cluster.role("app")
+	require.NotNil(t, role)
+	assert.True(t, role.login)
+	assert.Contains(t, role.memberOf, "parent")
+
+	var created roleResourceData
+	getTestState(t, createResp.State, &created)
+	assert.Equal(t, "app", created.Id.Value)
+	assert.Equal(t, role.saltedHash, created.SaltedHash.Value)
+
+	// Changes made outside of Terraform are detected.
+	cluster.mu.Lock()
+	role.login = false
+	delete(role.memberOf, "parent")
+	cluster.mu.Unlock()
+
+	readResp := r.read(createResp.State)
+	require.False(t, readResp.Diagnostics.HasError(), "%v", readResp.Diagnostics)
+
+	var read roleResourceData
+	getTestState(t, readResp.State, &read)
+	assert.False(t, read.Login.Value)
+	assert.Empty(t, read.MemberOf.Elems)
+	assert.Equal(t, "secret", read.Password.Value)
+
+	// Attributes that only affect the provider do not alter the role.
+	executed := len(cluster.executed)
+	read.DeletionProtection = types.Bool{Value: false}
+	updateResp := r.update(readResp.State, &read)
+	require.False(t, updateResp.Diagnostics.HasError(), "%v", updateResp.Diagnostics)
+	assert.Len(t, cluster.executed, executed)
+
+	deleteResp := r.delete(updateResp.State)
+	require.False(t, deleteResp.Diagnostics.HasError(), "%v", deleteResp.Diagnostics)
+	assert.Nil(t, cluster.role("app"))
+
+	// A role dropped outside of Terraform is removed from the state.
+	goneResp := r.read(readResp.State)
+	require.False(t, goneResp.Diagnostics.HasError(), "%v", goneResp.Diagnostics)
+	assert.True(t, goneResp.State.Raw.IsNull())
+}
+
+func TestRoleResourceAdoptExisting(t *testing.T) {
+	cluster := newFakeCluster()
+	cluster.addRole("parent")
+	cluster.addRole("old")
+	existing := cluster.addRole("app")
+	existing.memberOf["old"] = struct{}{}
+	r := newTestResource(t, roleResourceType{}, cluster.provider())
+
+	config := newTestRoleData("app")
+	config.MemberOf = types.Set{ElemType: types.StringType, Elems: []attr.Value{types.String{Value: "parent"}}}
+
+	resp := r.create(&config)
+	assert.True(t, resp.Diagnostics.HasError(), "an existing role is not adopted by default")
+
+	config.AdoptExisting = types.Bool{Value: true}
+	resp = r.create(&config)
+	require.False(t, resp.Diagnostics.HasError(), "%v", resp.Diagnostics)
+
+	role := cluster.role("app")
+	assert.True(t, role.login)
+	assert.Equal(t, map[string]struct{}{"parent": {}}, role.memberOf)
+
+	var created roleResourceData
+	getTestState(t, resp.State, &created)
+	assert.Equal(t, "app", created.Id.Value)
+}
+
+func TestRoleResourceImportState(t *testing.T) {
+	cluster := newFakeCluster()
+	cluster.addRole("parent")
+	app := cluster.addRole("app")
+	app.login = true
+	app.memberOf["parent"] = struct{}{}
+	cluster.addRole("standalone")
+	r := newTestResource(t, roleResourceType{}, cluster.provider())
+
+	resp := r.importState("app")
+	require.False(t, resp.Diagnostics.HasError(), "%v", resp.Diagnostics)
+
+	var imported roleResourceData
+	getTestState(t, resp.State, &imported)
+	assert.Equal(t, "app", imported.Name.Value)
+	assert.Equal(t, "app", imported.Id.Value)
+	assert.True(t, imported.Login.Value)
+	assert.False(t, imported.Superuser.Value)
+	assert.Equal(t, []attr.Value{types.String{Value: "parent"}}, imported.MemberOf.Elems)
+
+	// Roles without memberships leave member_of unmanaged.
+	resp = r.importState("standalone")
+	require.False(t, resp.Diagnostics.HasError(), "%v", resp.Diagnostics)
+	getTestState(t, resp.State, &imported)
+	assert.True(t, imported.MemberOf.IsNull())
+
+	resp = r.importState("missing")
+	require.True(t, resp.Diagnostics.HasError())
+	assert.Equal(t, "Role not found", resp.Diagnostics[0].Summary())
+}
+
+func TestRoleResourceDeleteProtection(t *testing.T) {
+	tests := []struct {
+		name     string
+		modify   func(data *roleResourceData)
+		username string
+		dropped  bool
+		wantErr  bool
+	}{
+		{
+			name:    "default",
+			modify:  func(data *roleResourceData) {},
+			dropped: true,
+		},
+		{
+			name:   "drop_on_destroy false",
+			modify: func(data *roleResourceData) { data.DropOnDestroy = types.Bool{Value: false} },
+		},
+		{
+			name: "drop_on_destroy false with deletion_protection",
+			modify: func(data *roleResourceData) {
+				data.DropOnDestroy = types.Bool{Value: false}
+				data.DeletionProtection = types.Bool{Value: true}
+			},
+		},
+		{
+			name:     "drop_on_destroy false for connected role",
+			modify:   func(data *roleResourceData) { data.DropOnDestroy = types.Bool{Value: false} },
+			username: "app",
+		},
+		{
+			name:    "deletion_protection",
+			modify:  func(data *roleResourceData) { data.DeletionProtection = types.Bool{Value: true} },
+			wantErr: true,
+		},
+		{
+			name:    "deletion_protection disabled",
+			modify:  func(data *roleResourceData) { data.DeletionProtection = types.Bool{Value: false} },
+			dropped: true,
+		},
+		{
+			name:     "connected role",
+			modify:   func(data *roleResourceData) {},
+			username: "app",
+			wantErr:  true,
+		},
+		{
+			name:     "connected role with allow_self_destroy",
+			modify:   func(data *roleResourceData) { data.AllowSelfDestroy = types.Bool{Value: true} },
+			username: "app",
+			dropped:  true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			cluster := newFakeCluster()
+			cluster.addRole("app")
+			p := cluster.provider()
+			p.username = test.username
+			r := newTestResource(t, roleResourceType{}, p)
+
+			data := newTestRoleData("app")
+			data.Id = data.Name
+			data.SaltedHash = types.String{Null: true}
+			test.modify(&data)
+
+			resp := r.delete(r.state(&data))
+			assert.Equal(t, test.wantErr, resp.Diagnostics.HasError(), "%v", resp.Diagnostics)
+			assert.Equal(t, test.dropped, cluster.role("app") == nil)
+		})
+	}
+}
+
+func TestRoleResourceSaltedHashChangedOutside(t *testing.T) {
+	ctx := context.Background()
+	cluster := newFakeCluster()
+	r := newTestResource(t, roleResourceType{}, cluster.provider())
+	assert.True(t, r.schema.Attributes["salted_hash"].Sensitive)
+
+	config := newTestRoleData("app")
+	config.Password = types.String{Value: "secret"}
+	createResp := r.create(&config)
+	require.False(t, createResp.Diagnostics.HasError(), "%v", createResp.Diagnostics)
+
+	// plan runs the plan modifier of salted_hash with the configuration and the refreshed state.
+	plan := func(state tfsdk.State) attr.Value {
+		var hash types.String
+		require.False(t, state.GetAttribute(ctx, path.Root("salted_hash"), &hash).HasError())
+		planned := r.state(&config)
+		resp := tfsdk.ModifyAttributePlanResponse{AttributePlan: types.String{Unknown: true}}
+		saltedHashPlanModifier{}.Modify(ctx, tfsdk.ModifyAttributePlanRequest{
+			AttributePath:  path.Root("salted_hash"),
+			AttributeState: hash,
+			AttributePlan:  types.String{Unknown: true},
+			State:          state,
+			Plan:           tfsdk.Plan{Schema: r.schema, Raw: planned.Raw},
+			Config:         tfsdk.Config{Schema: r.schema, Raw: planned.Raw},
+		}, &resp)
+		require.False(t, resp.Diagnostics.HasError(), "%v", resp.Diagnostics)
+		return resp.AttributePlan
+	}
+
+	// Without changes, the hash is kept from the state.
+	readResp := r.read(createResp.State)
+	require.False(t, readResp.Diagnostics.HasError(), "%v", readResp.Diagnostics)
+	assert.Equal(t, types.String{Value: cluster.role("app").saltedHash}, plan(readResp.State))
+
+	// The password was changed outside of Terraform, so the hash is planned to change with the password.
+	hash, err := bcrypt.GenerateFromPassword([]byte("other"), bcrypt.MinCost)
+	require.NoError(t, err)
+	role := cluster.role("app")
+	cluster.mu.Lock()
+	role.saltedHash = string(hash)
+	cluster.mu.Unlock()
+
+	readResp = r.read(createResp.State)
+	require.False(t, readResp.Diagnostics.HasError(), "%v", readResp.Diagnostics)
+	var read roleResourceData
+	getTestState(t, readResp.State, &read)
+	assert.True(t, read.Password.IsUnknown())
+	assert.Equal(t, string(hash), read.SaltedHash.Value)
+	assert.Equal(t, types.String{Unknown: true}, plan(readResp.State))
+
+	// Without a password in the configuration, the change is reported as a warning.
+	var noPassword roleResourceData
+	getTestState(t, createResp.State, &noPassword)
+	noPassword.Password = types.String{Null: true}
+	readResp = r.read(r.state(&noPassword))
+	require.False(t, readResp.Diagnostics.HasError(), "%v", readResp.Diagnostics)
+	require.Len(t, readResp.Diagnostics, 1)
+	assert.Equal(t, diag.SeverityWarning, readResp.Diagnostics[0].Severity())
+	assert.Equal(t, "Password changed outside of Terraform", readResp.Diagnostics[0].Summary())
+}
@@ -0,0 +1,238 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/scylladb/scylla-go-driver/frame"
+	"github.com/scylladb/scylla-go-driver/frame/response"
+	"github.com/scylladb/scylla-go-driver/transport"
+
+	"github.com/kiwicom/terraform-provider-scylla/internal/qb"
+	"github.com/kiwicom/terraform-provider-scylla/internal/scan"
+)
+
+// roleTables are the tables holding roles, in the order they are tried.
+// Scylla moved them from the system_auth keyspace to the system keyspace when auth became managed by Raft.
+var roleTables = []string{"system.roles", "system_auth.roles"}
+
+// roleInfo holds the attributes of a role.
+type roleInfo struct {
+	login     bool
+	superuser bool
+
+	// memberOf are the roles directly granted to the role.
+	memberOf []string
+
+	// saltedHash is the salted hash of the password, empty if the role has no password.
+	saltedHash string
+
+	// hasSaltedHash is false if the salted hash could not be read from any of the role tables.
+	hasSaltedHash bool
+}
+
+// readRole reads the role using LIST ROLES, which is independent of where the server stores roles.
+// The salted hash, which LIST ROLES does not return, is read from the first of roleTables that can be queried.
+// If LIST ROLES fails, the whole role is read from roleTables instead.
+// The returned bool is false if the role does not exist.
+func (p *provider) readRole(ctx context.Context, name string) (roleInfo, bool, error) {
+	info, found, listErr := p.listRole(ctx, name)
+	if listErr == nil && !found {
+		return roleInfo{}, false, nil
+	}
+
+	values, err := bind(name)
+	if err != nil {
+		return roleInfo{}, false, err
+	}
+
+	var tableErrs []string
+	for _, table := range roleTables {
+		result, err := p.executeAuthRead(ctx,
+			fmt.Sprintf("SELECT can_login, is_superuser, member_of, salted_hash FROM %s WHERE role = ?", table),
+			values)
+		if err != nil {
+			if !isUnavailableTableError(err) {
+				return roleInfo{}, false, err
+			}
+			tableErrs = append(tableErrs, fmt.Sprintf("%s: %s", table, err))
+			continue
+		}
+
+		if len(result.Rows) == 0 {
+			if listErr == nil {
+				// The role exists, but it is not stored in this table.
+				continue
+			}
+			return roleInfo{}, false, nil
+		}
+
+		var row struct {
+			CanLogin    bool     `cql:"can_login"`
+			IsSuperuser bool     `cql:"is_superuser"`
+			MemberOf    []string `cql:"member_of"`
+			SaltedHash  string   `cql:"salted_hash"`
+		}
+		if err := scan.Row(result, 0, &row); err != nil {
+			return roleInfo{}, false, err
+		}
+		if listErr != nil {
+			info.login = row.CanLogin
+			info.superuser = row.IsSuperuser
+			info.memberOf = row.MemberOf
+		}
+		info.saltedHash = row.SaltedHash
+		info.hasSaltedHash = true
+		return info, true, nil
+	}
+
+	if listErr != nil {
+		return roleInfo{}, false, fmt.Errorf("%w, role tables unavailable: %s", listErr, strings.Join(tableErrs, "; "))
+	}
+	return info, true, nil
+}
+
+// listRole reads the role using LIST ROLES OF. The salted hash is not set.
+func (p *provider) listRole(ctx context.Context, name string) (roleInfo, bool, error) {
+	var stmt qb.Builder
+	stmt.Appendf("LIST ROLES OF %s NORECURSIVE", qb.QName(name))
+	result, err := p.executeAuthRead(ctx, stmt.String(), nil)
+	if err != nil {
+		if isNotFoundError(err) {
+			return roleInfo{}, false, nil
+		}
+		return roleInfo{}, false, err
+	}
+
+	info, found, err := parseListRoles(name, result)
+	if err != nil {
+		return roleInfo{}, false, fmt.Errorf("unable to read LIST ROLES result: %w", err)
+	}
+	return info, found, nil
+}
+
+// listedRole is a role returned by listRoles.
+type listedRole struct {
+	name      string
+	login     bool
+	superuser bool
+}
+
+// listRoles returns all roles using LIST ROLES, like readRole reads a single one.
+// LIST ROLES returns only the roles granted to the connected role unless it is a superuser, so in that case,
+// as when LIST ROLES fails, the roles are read from the first of roleTables that can be queried instead.
+// The returned bool is false if the role tables cannot be read and only the roles granted to the connected role
+// are returned.
+func (p *provider) listRoles(ctx context.Context) ([]listedRole, bool, error) {
+	listed, listErr := p.listAllRoles(ctx)
+	if listErr == nil && p.listsAllRoles(listed) {
+		return listed, true, nil
+	}
+
+	var tableErrs []string
+	for _, table := range roleTables {
+		result, err := p.executeAuthRead(ctx, fmt.Sprintf("SELECT role, can_login, is_superuser FROM %s", table), nil)
+		if err != nil {
+			if !isUnavailableTableError(err) {
+				return nil, false, err
+			}
+			tableErrs = append(tableErrs, fmt.Sprintf("%s: %s", table, err))
+			continue
+		}
+
+		var rows []struct {
+			Role        string `cql:"role"`
+			CanLogin    bool   `cql:"can_login"`
+			IsSuperuser bool   `cql:"is_superuser"`
+		}
+		if err := scan.Rows(result, &rows); err != nil {
+			return nil, false, err
+		}
+		roles := make([]listedRole, 0, len(rows))
+		for _, row := range rows {
+			roles = append(roles, listedRole{name: row.Role, login: row.CanLogin, superuser: row.IsSuperuser})
+		}
+		return roles, true, nil
+	}
+	if listErr == nil {
+		return listed, false, nil
+	}
+	return nil, false, fmt.Errorf("%w, role tables unavailable: %s", listErr, strings.Join(tableErrs, "; "))
+}
+
+// listAllRoles reads the result of LIST ROLES.
+func (p *provider) listAllRoles(ctx context.Context) ([]listedRole, error) {
+	result, err := p.executeAuthRead(ctx, "LIST ROLES", nil)
+	if err != nil {
+		return nil, err
+	}
+	var rows []struct {
+		Role  string `cql:"role"`
+		Super bool   `cql:"super"`
+		Login bool   `cql:"login"`
+	}
+	if err := scan.Rows(result, &rows); err != nil {
+		return nil, fmt.Errorf("unable to read LIST ROLES result: %w", err)
+	}
+	roles := make([]listedRole, 0, len(rows))
+	for _, row := range rows {
+		roles = append(roles, listedRole{name: row.Role, login: row.Login, superuser: row.Super})
+	}
+	return roles, nil
+}
+
+// listsAllRoles reports whether LIST ROLES returned all roles, which it does for superusers.
+// Without authentication, there is no connected role and all roles are returned.
+func (p *provider) listsAllRoles(listed []listedRole) bool {
+	if p.username == "" {
+		return true
+	}
+	for _, role := range listed {
+		if role.name == p.username {
+			return role.superuser
+		}
+	}
+	return false
+}
+
+// parseListRoles extracts the role from the result of LIST ROLES OF name NORECURSIVE, which returns
+// the role itself together with the roles directly granted to it.
+func parseListRoles(name string, result transport.QueryResult) (roleInfo, bool, error) {
+	var rows []struct {
+		Role  string `cql:"role"`
+		Super bool   `cql:"super"`
+		Login bool   `cql:"login"`
+	}
+	if err := scan.Rows(result, &rows); err != nil {
+		return roleInfo{}, false, err
+	}
+
+	var info roleInfo
+	found := false
+	for _, row := range rows {
+		if row.Role != name {
+			info.memberOf = append(info.memberOf, row.Role)
+			continue
+		}
+		found = true
+		info.superuser = row.Super
+		info.login = row.Login
+	}
+	return info, found, nil
+}
+
+// isUnavailableTableError reports whether err means that the table does not exist or cannot be read by the user.
+func isUnavailableTableError(err error) bool {
+	var codedErr response.CodedError
+	if !errors.As(err, &codedErr) {
+		return false
+	}
+	switch codedErr.ErrorCode() {
+	case frame.ErrCodeInvalid, frame.ErrCodeUnauthorized:
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,127 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces
+var _ tfsdk.DataSourceType = rolesDataSourceType{}
+var _ tfsdk.DataSource = rolesDataSource{}
+
+type rolesDataSourceType struct{}
+
+func (t rolesDataSourceType) GetSchema(ctx context.Context) (tfsdk.Schema, diag.Diagnostics) {
+	return tfsdk.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Lists Scylla roles",
+
+		Attributes: map[string]tfsdk.Attribute{
+			"name_prefix": {
+				MarkdownDescription: "Only roles with names starting with this prefix are returned.",
+				Optional:            true,
+				Type:                types.StringType,
+			},
+			"id": {
+				MarkdownDescription: "Identifier of the data source",
+				Computed:            true,
+				Type:                types.StringType,
+			},
+			"roles": {
+				MarkdownDescription: "Roles sorted by name.",
+				Computed:            true,
+				Attributes: tfsdk.ListNestedAttributes(map[string]tfsdk.Attribute{
+					"name": {
+						MarkdownDescription: "Name of the role",
+						Computed:            true,
+						Type:                types.StringType,
+					},
+					"login": {
+						MarkdownDescription: "Indicates whether the role is allowed to login.",
+						Computed:            true,
+						Type:                types.BoolType,
+					},
+					"superuser": {
+						MarkdownDescription: "Indicates whether the role has all permissions.",
+						Computed:            true,
+						Type:                types.BoolType,
+					},
+				}),
+			},
+		},
+	}, nil
+}
+
+func (t rolesDataSourceType) NewDataSource(ctx context.Context, in tfsdk.Provider) (tfsdk.DataSource, diag.Diagnostics) {
+	provider, diags := convertProviderType(in)
+
+	return rolesDataSource{
+		provider: provider,
+	}, diags
+}
+
+type rolesDataSourceData struct {
+	NamePrefix types.String              `tfsdk:"name_prefix"`
+	Id         types.String              `tfsdk:"id"`
+	Roles      []rolesDataSourceRoleData `tfsdk:"roles"`
+}
+
+type rolesDataSourceRoleData struct {
+	Name      types.String `tfsdk:"name"`
+	Login     types.Bool   `tfsdk:"login"`
+	Superuser types.Bool   `tfsdk:"superuser"`
+}
+
+type rolesDataSource struct {
+	provider provider
+}
+
+func (d rolesDataSource) Read(ctx context.Context, req tfsdk.ReadDataSourceRequest, resp *tfsdk.ReadDataSourceResponse) {
+	var data rolesDataSourceData
+
+	diags := req.Config.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	roles, complete, err := d.provider.listRoles(ctx)
+	if err != nil {
+		resp.Diagnostics.Append(cqlErrorDiagnostic("Query error", "Unable to list roles.", err))
+		return
+	}
+	if !complete {
+		resp.Diagnostics.AddWarning("Incomplete list of roles",
+			fmt.Sprintf("Role %q is not a superuser, so only the roles granted to it are listed. "+
+				"Connect as a superuser, or allow the role to read %s, to list all roles.",
+				d.provider.username, strings.Join(roleTables, " or ")))
+	}
+
+	data.Roles = make([]rolesDataSourceRoleData, 0, len(roles))
+	for _, role := range roles {
+		if !strings.HasPrefix(role.name, data.NamePrefix.Value) {
+			continue
+		}
+		data.Roles = append(data.Roles, rolesDataSourceRoleData{
+			Name:      types.String{Value: role.name},
+			Login:     types.Bool{Value: role.login},
+			Superuser: types.Bool{Value: role.superuser},
+		})
+	}
+
+	sort.Slice(data.Roles, func(i, j int) bool {
+		return data.Roles[i].Name.Value < data.Roles[j].Name.Value
+	})
+
+	data.Id = types.String{Value: fmt.Sprintf("roles/%s", data.NamePrefix.Value)}
+
+	diags = resp.State.Set(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+}
@@ -0,0 +1,80 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRolesDataSourceRead(t *testing.T) {
+	for _, denyListRoles := range []bool{false, true} {
+		cluster := newFakeCluster()
+		cluster.denyListRoles = denyListRoles
+		writer := cluster.addRole("app_writer")
+		writer.login = true
+		writer.superuser = true
+		cluster.addRole("app_reader")
+		cluster.addRole("batch")
+
+		ds := newTestDataSource(t, rolesDataSourceType{}, cluster.provider())
+		resp := ds.read(&rolesDataSourceData{
+			NamePrefix: types.String{Value: "app_"},
+			Id:         types.String{Null: true},
+		})
+		require.False(t, resp.Diagnostics.HasError(), "denyListRoles=%v: %v", denyListRoles, resp.Diagnostics)
+
+		var data rolesDataSourceData
+		getTestState(t, resp.State, &data)
+		assert.Equal(t, "roles/app_", data.Id.Value)
+		assert.Equal(t, []rolesDataSourceRoleData{
+			{Name: types.String{Value: "app_reader"}, Login: types.Bool{Value: false}, Superuser: types.Bool{Value: false}},
+			{Name: types.String{Value: "app_writer"}, Login: types.Bool{Value: true}, Superuser: types.Bool{Value: true}},
+		}, data.Roles, "denyListRoles=%v", denyListRoles)
+
+		if denyListRoles {
+			assert.Contains(t, cluster.executed, "SELECT role, can_login, is_superuser FROM system_auth.roles")
+		} else {
+			assert.Equal(t, []string{"LIST ROLES"}, cluster.executed)
+		}
+	}
+}
+
+func TestRolesDataSourceReadNotSuperuser(t *testing.T) {
+	cluster := newFakeCluster()
+	admin := cluster.addRole("admin")
+	admin.superuser = true
+	cluster.addRole("app_reader")
+	app := cluster.addRole("app")
+	app.memberOf = map[string]struct{}{"app_reader": {}}
+
+	for _, user := range []string{"admin", "app"} {
+		cluster.user = user
+		p := cluster.provider()
+		p.username = user
+		ds := newTestDataSource(t, rolesDataSourceType{}, p)
+		resp := ds.read(&rolesDataSourceData{
+			NamePrefix: types.String{Null: true},
+			Id:         types.String{Null: true},
+		})
+		require.False(t, resp.Diagnostics.HasError(), "user=%s: %v", user, resp.Diagnostics)
+
+		var data rolesDataSourceData
+		getTestState(t, resp.State, &data)
+		var names []string
+		for _, role := range data.Roles {
+			names = append(names, role.Name.Value)
+		}
+		if user == "admin" {
+			assert.Equal(t, []string{"admin", "app", "app_reader"}, names)
+			assert.Empty(t, resp.Diagnostics)
+			continue
+		}
+		// LIST ROLES returns only the roles granted to a role that is not a superuser,
+		// which cannot read the role tables either.
+		assert.Equal(t, []string{"app", "app_reader"}, names)
+		require.Len(t, resp.Diagnostics, 1)
+		assert.Equal(t, "Incomplete list of roles", resp.Diagnostics[0].Summary())
+	}
+}
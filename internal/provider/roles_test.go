@@ -0,0 +1,38 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/scylladb/scylla-go-driver/frame"
+	"github.com/scylladb/scylla-go-driver/transport"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseListRoles(t *testing.T) {
+	text := func(s string) frame.CqlValue {
+		v, err := frame.CqlFromText(s)
+		require.NoError(t, err)
+		return v
+	}
+	result := transport.QueryResult{
+		ColSpec: []frame.ColumnSpec{{Name: "role"}, {Name: "super"}, {Name: "login"}, {Name: "options"}},
+		Rows: []frame.Row{
+			{text("app"), frame.CqlFromBoolean(false), frame.CqlFromBoolean(true), frame.CqlValue{}},
+			{text("reader"), frame.CqlFromBoolean(false), frame.CqlFromBoolean(false), frame.CqlValue{}},
+			{text("writer"), frame.CqlFromBoolean(true), frame.CqlFromBoolean(false), frame.CqlValue{}},
+		},
+	}
+
+	info, found, err := parseListRoles("app", result)
+	require.NoError(t, err)
+	assert.True(t, found)
+	assert.True(t, info.login)
+	assert.False(t, info.superuser)
+	assert.Equal(t, []string{"reader", "writer"}, info.memberOf)
+	assert.False(t, info.hasSaltedHash)
+
+	_, found, err = parseListRoles("other", result)
+	require.NoError(t, err)
+	assert.False(t, found)
+}
@@ -0,0 +1,155 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/scylladb/scylla-go-driver/frame"
+	"github.com/scylladb/scylla-go-driver/transport"
+)
+
+// schemaAgreementInterval is the delay between checks of schema versions.
+const schemaAgreementInterval = 200 * time.Millisecond
+
+// awaitSchemaAgreement waits until all live nodes report the same schema version,
+// so that objects created by a schema-altering statement can be used right away.
+// The statement has already been applied, so failing to reach agreement is only logged as a warning:
+// returning an error would leave the object in the cluster but not in the state.
+func (s *session) awaitSchemaAgreement(ctx context.Context) {
+	waitForSchemaAgreement(ctx, s.schemaAgreementTimeout, s.checkSchemaAgreement)
+}
+
+// waitForSchemaAgreement calls check until it reports agreement or the timeout expires.
+func waitForSchemaAgreement(ctx context.Context, timeout time.Duration, check func(context.Context) (bool, error)) {
+	if timeout <= 0 {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	ticker := time.NewTicker(schemaAgreementInterval)
+	defer ticker.Stop()
+
+	for {
+		agreement, err := check(ctx)
+		if err != nil {
+			tflog.Warn(ctx, "Unable to check schema agreement, objects may not be usable on all nodes right away",
+				map[string]interface{}{"error": err.Error()})
+			return
+		}
+		if agreement {
+			return
+		}
+
+		tflog.Debug(ctx, "waiting for schema agreement")
+
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			tflog.Warn(ctx, "Schema not in agreement, objects may not be usable on all nodes right away",
+				map[string]interface{}{"timeout": timeout.String()})
+			return
+		}
+	}
+}
+
+// checkSchemaAgreement reports whether the node the provider is connected to and all its live peers
+// have the same schema version.
+func (s *session) checkSchemaAgreement(ctx context.Context) (bool, error) {
+	conn, err := s.conn()
+	if err != nil {
+		return false, err
+	}
+
+	query := func(content string) (transport.QueryResult, error) {
+		return conn.Query(ctx, transport.Statement{Content: content, Consistency: frame.ONE}, nil)
+	}
+	local, err := query("SELECT schema_version FROM system.local")
+	if err != nil {
+		return false, err
+	}
+	peers, err := query("SELECT peer, rpc_address, schema_version FROM system.peers")
+	if err != nil {
+		return false, err
+	}
+	return schemaAgreement(local, peers, s.liveNodes())
+}
+
+// schemaAgreement reports whether the schema versions of the local node and the peers are the same.
+// Peers that are not in live are skipped, as down nodes keep reporting their old schema version
+// until they come back. If live is nil, all peers are checked.
+func schemaAgreement(local, peers transport.QueryResult, live map[string]struct{}) (bool, error) {
+	versions := make(map[frame.UUID]struct{})
+	add := func(v frame.CqlValue, table string) error {
+		if v.Value == nil {
+			// Peer that has not joined the cluster yet.
+			return nil
+		}
+		version, err := v.AsUUID()
+		if err != nil {
+			return fmt.Errorf("read schema_version from %s: %w", table, err)
+		}
+		versions[version] = struct{}{}
+		return nil
+	}
+
+	for _, row := range local.Rows {
+		if err := add(row[0], "system.local"); err != nil {
+			return false, err
+		}
+	}
+	for _, row := range peers.Rows {
+		if live != nil && !peerLive(row, live) {
+			continue
+		}
+		if err := add(row[2], "system.peers"); err != nil {
+			return false, err
+		}
+	}
+	return len(versions) <= 1, nil
+}
+
+// peerLive reports whether the peer or RPC address of the system.peers row is in live.
+func peerLive(row frame.Row, live map[string]struct{}) bool {
+	for _, v := range row[:2] {
+		if v.Value == nil {
+			continue
+		}
+		ip, err := v.AsIP()
+		if err != nil {
+			continue
+		}
+		if _, ok := live[ip.String()]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+// liveNodes returns the IP addresses of the nodes the driver considers up,
+// nil if it is not known, as when connected through a proxy.
+func (s *session) liveNodes() map[string]struct{} {
+	s.mu.Lock()
+	cluster := s.cluster
+	s.mu.Unlock()
+	if cluster == nil {
+		return nil
+	}
+
+	live := make(map[string]struct{})
+	info := cluster.NewQueryInfo()
+	for i := 0; ; i++ {
+		n := s.policy.Node(info, i)
+		if n == nil {
+			break
+		}
+		if addr := nodeAddr(n); addr != "" {
+			live[net.ParseIP(addr).String()] = struct{}{}
+		}
+	}
+	return live
+}
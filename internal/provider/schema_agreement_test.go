@@ -0,0 +1,65 @@
+package provider
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/scylladb/scylla-go-driver/frame"
+	"github.com/scylladb/scylla-go-driver/transport"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func fakeUUID(b byte) frame.CqlValue {
+	v := frame.CqlValue{Type: &frame.Option{ID: frame.UUIDID}, Value: make([]byte, 16)}
+	v.Value[15] = b
+	return v
+}
+
+func fakeInet(ip string) frame.CqlValue {
+	return frame.CqlValue{Type: &frame.Option{ID: frame.InetID}, Value: net.ParseIP(ip).To4()}
+}
+
+func TestSchemaAgreement(t *testing.T) {
+	local := transport.QueryResult{Rows: []frame.Row{{fakeUUID(1)}}}
+	peers := transport.QueryResult{Rows: []frame.Row{
+		{fakeInet("10.0.0.2"), fakeInet("10.0.0.2"), fakeUUID(1)},
+		// Down peer still reporting the schema version before the change.
+		{fakeInet("10.0.0.3"), fakeInet("10.0.0.3"), fakeUUID(2)},
+		// Peer that has not joined yet.
+		{fakeInet("10.0.0.4"), fakeInet("10.0.0.4"), frame.CqlValue{Type: &frame.Option{ID: frame.UUIDID}}},
+	}}
+
+	agreement, err := schemaAgreement(local, peers, map[string]struct{}{"10.0.0.1": {}, "10.0.0.2": {}})
+	require.NoError(t, err)
+	assert.True(t, agreement, "down peers must be skipped")
+
+	agreement, err = schemaAgreement(local, peers, map[string]struct{}{"10.0.0.2": {}, "10.0.0.3": {}})
+	require.NoError(t, err)
+	assert.False(t, agreement)
+
+	agreement, err = schemaAgreement(local, peers, nil)
+	require.NoError(t, err)
+	assert.False(t, agreement, "all peers must be checked if their status is not known")
+}
+
+func TestWaitForSchemaAgreementTimeout(t *testing.T) {
+	checks := 0
+	start := time.Now()
+	// One peer never converges, waiting gives up after the timeout without failing the statement.
+	waitForSchemaAgreement(context.Background(), 300*time.Millisecond, func(context.Context) (bool, error) {
+		checks++
+		return false, nil
+	})
+	assert.GreaterOrEqual(t, time.Since(start), 300*time.Millisecond)
+	assert.GreaterOrEqual(t, checks, 2)
+
+	checks = 0
+	waitForSchemaAgreement(context.Background(), time.Minute, func(context.Context) (bool, error) {
+		checks++
+		return checks == 2, nil
+	})
+	assert.Equal(t, 2, checks)
+}
@@ -0,0 +1,149 @@
+package provider
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/kiwicom/terraform-provider-scylla/internal/scan"
+)
+
+// schemaCacheTTL limits how long listed keyspaces and tables are reused, like permissionCacheTTL.
+const schemaCacheTTL = 30 * time.Second
+
+// schemaObjects holds the names of all keyspaces and tables.
+type schemaObjects struct {
+	keyspaces map[string]struct{}
+
+	// tables holds the tables in the form keyspace.table.
+	tables map[string]struct{}
+}
+
+// schemaCache holds the keyspaces and tables listed from the schema tables, so that refreshing many grants
+// checks that their objects exist with a single listing. The zero value is ready to use.
+type schemaCache struct {
+	mu    sync.Mutex
+	entry *schemaCacheEntry
+
+	// now returns the current time, it is replaced in tests.
+	now func() time.Time
+}
+
+type schemaCacheEntry struct {
+	// done is closed once the objects are fetched.
+	done chan struct{}
+
+	fetched time.Time
+	objects schemaObjects
+	err     error
+}
+
+// get returns the cached objects, calling fetch if they are not cached or expired.
+// Concurrent calls share a single fetch. Errors are not cached.
+func (c *schemaCache) get(ctx context.Context,
+	fetch func(ctx context.Context) (schemaObjects, error)) (schemaObjects, error) {
+	if c == nil {
+		return fetch(ctx)
+	}
+
+	c.mu.Lock()
+	e := c.entry
+	ok := e != nil
+	if ok {
+		select {
+		case <-e.done:
+			if c.clock().Sub(e.fetched) > schemaCacheTTL {
+				ok = false
+			}
+		default:
+			// Fetch in progress.
+		}
+	}
+	if !ok {
+		e = &schemaCacheEntry{done: make(chan struct{})}
+		c.entry = e
+		c.mu.Unlock()
+
+		e.objects, e.err = fetch(ctx)
+		e.fetched = c.clock()
+		c.mu.Lock()
+		if e.err != nil && c.entry == e {
+			c.entry = nil
+		}
+		c.mu.Unlock()
+		close(e.done)
+		return e.objects, e.err
+	}
+	c.mu.Unlock()
+
+	select {
+	case <-e.done:
+	case <-ctx.Done():
+		return schemaObjects{}, ctx.Err()
+	}
+	if e.err != nil {
+		// The fetch was made for another caller, try on our own.
+		return c.get(ctx, fetch)
+	}
+	return e.objects, nil
+}
+
+func (c *schemaCache) clock() time.Time {
+	if c.now != nil {
+		return c.now()
+	}
+	return time.Now()
+}
+
+// cachedTargetExists is targetExists answered from the keyspaces and tables listed once for all grants,
+// see schemaCache.
+func (p *provider) cachedTargetExists(ctx context.Context, keyspace, table string) (bool, error) {
+	objects, err := p.schemaObjects.get(ctx, p.listSchemaObjects)
+	if err != nil {
+		return false, err
+	}
+	if table == "" {
+		_, ok := objects.keyspaces[keyspace]
+		return ok, nil
+	}
+	_, ok := objects.tables[keyspace+"."+table]
+	return ok, nil
+}
+
+// listSchemaObjects lists all keyspaces and tables from the schema tables.
+func (p *provider) listSchemaObjects(ctx context.Context) (schemaObjects, error) {
+	result, err := p.executeAuthRead(ctx, "SELECT keyspace_name FROM system_schema.keyspaces", nil)
+	if err != nil {
+		return schemaObjects{}, err
+	}
+	var keyspaces []struct {
+		Keyspace string `cql:"keyspace_name"`
+	}
+	if err := scan.Rows(result, &keyspaces); err != nil {
+		return schemaObjects{}, err
+	}
+
+	result, err = p.executeAuthRead(ctx, "SELECT keyspace_name, table_name FROM system_schema.tables", nil)
+	if err != nil {
+		return schemaObjects{}, err
+	}
+	var tables []struct {
+		Keyspace string `cql:"keyspace_name"`
+		Table    string `cql:"table_name"`
+	}
+	if err := scan.Rows(result, &tables); err != nil {
+		return schemaObjects{}, err
+	}
+
+	objects := schemaObjects{
+		keyspaces: make(map[string]struct{}, len(keyspaces)),
+		tables:    make(map[string]struct{}, len(tables)),
+	}
+	for _, row := range keyspaces {
+		objects.keyspaces[row.Keyspace] = struct{}{}
+	}
+	for _, row := range tables {
+		objects.tables[row.Keyspace+"."+row.Table] = struct{}{}
+	}
+	return objects, nil
+}
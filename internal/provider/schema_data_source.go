@@ -0,0 +1,119 @@
+package provider
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/kiwicom/terraform-provider-scylla/internal/qb"
+	"github.com/kiwicom/terraform-provider-scylla/internal/scan"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces
+var _ tfsdk.DataSourceType = schemaDataSourceType{}
+var _ tfsdk.DataSource = schemaDataSource{}
+
+type schemaDataSourceType struct{}
+
+func (t schemaDataSourceType) GetSchema(ctx context.Context) (tfsdk.Schema, diag.Diagnostics) {
+	return tfsdk.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Exports the schema as printed by `DESCRIBE`. Requires server-side DESCRIBE support (Scylla 5.0+).",
+
+		Attributes: map[string]tfsdk.Attribute{
+			"keyspace": {
+				MarkdownDescription: "Name of the keyspace to describe. The whole schema is described if not set.",
+				Optional:            true,
+				Type:                types.StringType,
+			},
+			"id": {
+				MarkdownDescription: "Identifier of the data source",
+				Computed:            true,
+				Type:                types.StringType,
+			},
+			"cql": {
+				MarkdownDescription: "CQL statements recreating the schema.",
+				Computed:            true,
+				Type:                types.StringType,
+			},
+			"sha256": {
+				MarkdownDescription: "Hex encoded SHA-256 checksum of `cql`.",
+				Computed:            true,
+				Type:                types.StringType,
+			},
+		},
+	}, nil
+}
+
+func (t schemaDataSourceType) NewDataSource(ctx context.Context, in tfsdk.Provider) (tfsdk.DataSource, diag.Diagnostics) {
+	provider, diags := convertProviderType(in)
+
+	return schemaDataSource{
+		provider: provider,
+	}, diags
+}
+
+type schemaDataSourceData struct {
+	Keyspace types.String `tfsdk:"keyspace"`
+	Id       types.String `tfsdk:"id"`
+	CQL      types.String `tfsdk:"cql"`
+	SHA256   types.String `tfsdk:"sha256"`
+}
+
+type schemaDataSource struct {
+	provider provider
+}
+
+func (d schemaDataSource) Read(ctx context.Context, req tfsdk.ReadDataSourceRequest, resp *tfsdk.ReadDataSourceResponse) {
+	var data schemaDataSourceData
+
+	diags := req.Config.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var stmt qb.Builder
+	if data.Keyspace.IsNull() {
+		stmt.Append("DESC SCHEMA")
+	} else {
+		stmt.Appendf("DESC KEYSPACE %s", qb.QName(data.Keyspace.Value))
+	}
+
+	result, err := d.provider.execute(ctx, stmt.String(), nil)
+	if err != nil {
+		resp.Diagnostics.Append(cqlErrorDiagnostic("Query error",
+			fmt.Sprintf("Unable to describe schema:\n%s", stmt.String()), err))
+		return
+	}
+
+	var rows []struct {
+		CreateStatement string `cql:"create_statement"`
+	}
+	if err := scan.Rows(result, &rows); err != nil {
+		resp.Diagnostics.AddError("Query error", err.Error())
+		return
+	}
+
+	statements := make([]string, 0, len(rows))
+	for _, row := range rows {
+		statements = append(statements, row.CreateStatement)
+	}
+
+	cql := strings.Join(statements, "\n\n")
+	sum := sha256.Sum256([]byte(cql))
+
+	data.Id = types.String{Value: stmt.String()}
+	data.CQL = types.String{Value: cql}
+	data.SHA256 = types.String{Value: hex.EncodeToString(sum[:])}
+
+	diags = resp.State.Set(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+}
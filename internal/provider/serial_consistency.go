@@ -0,0 +1,92 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/scylladb/scylla-go-driver/frame"
+	"github.com/scylladb/scylla-go-driver/transport"
+)
+
+// serialConsistencies maps names of serial consistency levels to their protocol values.
+// The serial consistency is used by the Paxos phase of lightweight transactions, statements with an IF condition.
+var serialConsistencies = map[string]frame.Consistency{
+	"SERIAL":       frame.SERIAL,
+	"LOCAL_SERIAL": frame.LOCALSERIAL,
+}
+
+// parseSerialConsistency returns the serial consistency level with the given name.
+func parseSerialConsistency(name string) (frame.Consistency, error) {
+	c, ok := serialConsistencies[strings.ToUpper(name)]
+	if !ok {
+		names := make([]string, 0, len(serialConsistencies))
+		for k := range serialConsistencies {
+			names = append(names, k)
+		}
+		sort.Strings(names)
+		return 0, fmt.Errorf("unsupported serial consistency %q, must be one of %s", name, names)
+	}
+	return c, nil
+}
+
+// serialConsistencyAttribute returns the schema of the serial_consistency attribute of resources
+// that use lightweight transactions.
+func serialConsistencyAttribute() tfsdk.Attribute {
+	return tfsdk.Attribute{
+		MarkdownDescription: "Serial consistency of the lightweight transactions of the resource, `SERIAL` or `LOCAL_SERIAL`. " +
+			"Defaults to `serial_consistency` of the provider.",
+		Optional: true,
+		Type:     types.StringType,
+	}
+}
+
+// validateSerialConsistency checks the serial_consistency attribute of a resource.
+// Unknown values are not checked, so that it can be called during validation of the configuration.
+func validateSerialConsistency(attrPath path.Path, serialConsistency types.String) diag.Diagnostics {
+	var diags diag.Diagnostics
+	if serialConsistency.IsNull() || serialConsistency.IsUnknown() {
+		return diags
+	}
+	if _, err := parseSerialConsistency(serialConsistency.Value); err != nil {
+		diags.AddAttributeError(attrPath, "Unsupported serial consistency", err.Error())
+	}
+	return diags
+}
+
+type serialConsistencyKey struct{}
+
+// withSerialConsistency returns ctx that makes the session execute statements with the serial consistency.
+func withSerialConsistency(ctx context.Context, c frame.Consistency) context.Context {
+	return context.WithValue(ctx, serialConsistencyKey{}, c)
+}
+
+// serialConsistencyFrom returns the serial consistency set by withSerialConsistency,
+// zero if it is not set, which leaves it to the server.
+func serialConsistencyFrom(ctx context.Context) frame.Consistency {
+	c, _ := ctx.Value(serialConsistencyKey{}).(frame.Consistency)
+	return c
+}
+
+// executeConditional executes a lightweight transaction with the configured consistency and the serial consistency
+// of the resource, or the one of the provider if the resource does not set it.
+func (p *provider) executeConditional(ctx context.Context, serialConsistency types.String, query string,
+	values []frame.CqlValue) (transport.QueryResult, error) {
+	c := p.serialConsistency
+	if !serialConsistency.IsNull() && !serialConsistency.IsUnknown() {
+		var err error
+		c, err = parseSerialConsistency(serialConsistency.Value)
+		if err != nil {
+			return transport.QueryResult{}, err
+		}
+	}
+	if c != 0 {
+		ctx = withSerialConsistency(ctx, c)
+	}
+	return p.executor.Execute(ctx, p.consistency, query, values)
+}
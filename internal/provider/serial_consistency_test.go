@@ -0,0 +1,55 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/scylladb/scylla-go-driver/frame"
+	"github.com/scylladb/scylla-go-driver/transport"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// serialConsistencyRecorder records the serial consistency of executed statements.
+type serialConsistencyRecorder struct {
+	serialConsistency frame.Consistency
+}
+
+func (r *serialConsistencyRecorder) Execute(ctx context.Context, _ frame.Consistency, _ string,
+	_ []frame.CqlValue) (transport.QueryResult, error) {
+	r.serialConsistency = serialConsistencyFrom(ctx)
+	return transport.QueryResult{}, nil
+}
+
+func TestParseSerialConsistency(t *testing.T) {
+	c, err := parseSerialConsistency("local_serial")
+	require.NoError(t, err)
+	assert.Equal(t, frame.LOCALSERIAL, c)
+
+	_, err = parseSerialConsistency("QUORUM")
+	assert.EqualError(t, err, `unsupported serial consistency "QUORUM", must be one of [LOCAL_SERIAL SERIAL]`)
+
+	assert.True(t, validateSerialConsistency(path.Root("serial_consistency"), types.String{Value: "ONE"}).HasError())
+	assert.False(t, validateSerialConsistency(path.Root("serial_consistency"), types.String{Unknown: true}).HasError())
+}
+
+func TestExecuteConditional(t *testing.T) {
+	ctx := context.Background()
+	recorder := &serialConsistencyRecorder{}
+	p := provider{executor: recorder, consistency: frame.LOCALQUORUM}
+
+	_, err := p.executeConditional(ctx, types.String{Null: true}, "INSERT INTO ks.t (k) VALUES (1) IF NOT EXISTS", nil)
+	require.NoError(t, err)
+	assert.Equal(t, frame.Consistency(0), recorder.serialConsistency, "left to the server")
+
+	p.serialConsistency = frame.SERIAL
+	_, err = p.executeConditional(ctx, types.String{Null: true}, "INSERT INTO ks.t (k) VALUES (1) IF NOT EXISTS", nil)
+	require.NoError(t, err)
+	assert.Equal(t, frame.SERIAL, recorder.serialConsistency)
+
+	_, err = p.executeConditional(ctx, types.String{Value: "LOCAL_SERIAL"}, "INSERT INTO ks.t (k) VALUES (1) IF NOT EXISTS", nil)
+	require.NoError(t, err)
+	assert.Equal(t, frame.LOCALSERIAL, recorder.serialConsistency, "the resource overrides the provider")
+}
@@ -3,25 +3,36 @@ package provider
 import (
 	"context"
 	"fmt"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/scylladb/scylla-go-driver/frame"
 
 	"github.com/kiwicom/terraform-provider-scylla/internal/qb"
+	"github.com/kiwicom/terraform-provider-scylla/internal/scan"
 )
 
 // Ensure provider defined types fully satisfy framework interfaces
 var _ tfsdk.ResourceType = serviceLevelResourceType{}
 var _ tfsdk.Resource = serviceLevelResource{}
+var _ tfsdk.ResourceWithUpgradeState = serviceLevelResource{}
 var _ tfsdk.ResourceWithImportState = serviceLevelResource{}
+var _ tfsdk.ResourceWithValidateConfig = serviceLevelResource{}
+var _ tfsdk.ResourceWithModifyPlan = serviceLevelResource{}
 
 type serviceLevelResourceType struct{}
 
 func (t serviceLevelResourceType) GetSchema(ctx context.Context) (tfsdk.Schema, diag.Diagnostics) {
 	return tfsdk.Schema{
+		// Version 1 added the timeouts block.
+		Version: 1,
+
 		// This description is used by the documentation generator and the language server.
 		MarkdownDescription: "Scylla role",
 
@@ -30,6 +41,9 @@ func (t serviceLevelResourceType) GetSchema(ctx context.Context) (tfsdk.Schema,
 				MarkdownDescription: "Name of the service level",
 				Required:            true,
 				Type:                types.StringType,
+				Validators: []tfsdk.AttributeValidator{
+					serviceLevelNameValidator(),
+				},
 				PlanModifiers: []tfsdk.AttributePlanModifier{
 					tfsdk.RequiresReplace(),
 				},
@@ -43,7 +57,7 @@ func (t serviceLevelResourceType) GetSchema(ctx context.Context) (tfsdk.Schema,
 				Type: types.StringType,
 			},
 			"shares": {
-				MarkdownDescription: "Number of shares granted to the service level. Values are in range 1 to 1000.",
+				MarkdownDescription: "Number of shares granted to the service level. Values are in range 1 to 1000. Only supported by Scylla Enterprise. Defaults to 1000.",
 				Optional:            true,
 				Type:                types.Int64Type,
 				Computed:            true,
@@ -52,7 +66,21 @@ func (t serviceLevelResourceType) GetSchema(ctx context.Context) (tfsdk.Schema,
 				},
 			},
 			"workload_type": {
-				MarkdownDescription: "Type of the workload. One of `unspecified`, `interactive` or `batch`.",
+				MarkdownDescription: "Type of the workload. One of `unspecified`, `interactive` or `batch`, case-insensitive. Defaults to `unspecified`.",
+				Optional:            true,
+				Type:                types.StringType,
+				Computed:            true,
+				PlanModifiers: tfsdk.AttributePlanModifiers{
+					tfsdk.UseStateForUnknown(),
+				},
+			},
+			"shares_percentage": {
+				MarkdownDescription: "Percentage of the shares of all service levels that belongs to this service level, which is the effective share of resources its workload gets under contention. Only available in Scylla Enterprise.",
+				Computed:            true,
+				Type:                types.Float64Type,
+			},
+			"timeout": {
+				MarkdownDescription: "Timeout as a duration, for example `500ms`, `2s` or `1m30s`. Statements are not limited by the service level if neither timeout attribute is set. Conflicts with `timeout_milliseconds`.",
 				Optional:            true,
 				Type:                types.StringType,
 				Computed:            true,
@@ -61,7 +89,7 @@ func (t serviceLevelResourceType) GetSchema(ctx context.Context) (tfsdk.Schema,
 				},
 			},
 			"timeout_milliseconds": {
-				MarkdownDescription: "Timeout in milliseconds.",
+				MarkdownDescription: "Timeout in milliseconds. Conflicts with `timeout`.",
 				Optional:            true,
 				Type:                types.Int64Type,
 				Computed:            true,
@@ -69,6 +97,16 @@ func (t serviceLevelResourceType) GetSchema(ctx context.Context) (tfsdk.Schema,
 					tfsdk.UseStateForUnknown(),
 				},
 			},
+			"adopt_existing": {
+				MarkdownDescription: "Adopt the service level if it exists already when the resource is created, instead of failing. " +
+					"Its configured attributes are then aligned with the configuration, the others are read from the cluster. " +
+					"Has no effect once the resource is created. Defaults to false.",
+				Optional: true,
+				Type:     types.BoolType,
+			},
+		},
+		Blocks: map[string]tfsdk.Block{
+			"timeouts": timeoutsBlock(),
 		},
 	}, nil
 }
@@ -82,21 +120,40 @@ func (t serviceLevelResourceType) NewResource(ctx context.Context, in tfsdk.Prov
 }
 
 type serviceLevelResourceData struct {
-	Name                types.String `tfsdk:"name"`
-	Id                  types.String `tfsdk:"id"`
-	Shares              types.Int64  `tfsdk:"shares"`
-	WorkloadType        types.String `tfsdk:"workload_type"`
-	TimeoutMilliseconds types.Int64  `tfsdk:"timeout_milliseconds"`
+	Name                types.String  `tfsdk:"name"`
+	Id                  types.String  `tfsdk:"id"`
+	Shares              types.Int64   `tfsdk:"shares"`
+	WorkloadType        types.String  `tfsdk:"workload_type"`
+	SharesPercentage    types.Float64 `tfsdk:"shares_percentage"`
+	Timeout             types.String  `tfsdk:"timeout"`
+	TimeoutMilliseconds types.Int64   `tfsdk:"timeout_milliseconds"`
+	AdoptExisting       types.Bool    `tfsdk:"adopt_existing"`
+
+	Timeouts []timeoutsData `tfsdk:"timeouts"`
 }
 
 func (s *serviceLevelResourceData) validate() diag.Diagnostics {
 	var diags diag.Diagnostics
-	if !s.Shares.IsNull() && (s.Shares.Value < 1 || s.Shares.Value > 1000) {
+	if !s.Shares.IsNull() && !s.Shares.IsUnknown() && (s.Shares.Value < 1 || s.Shares.Value > 1000) {
 		diags = append(diags, diag.NewAttributeErrorDiagnostic(path.Root("shares"),
 			"Out of range", "shares must be between 1 and 1000 (inclusive)."))
 	}
-	if !s.WorkloadType.IsNull() {
-		switch s.WorkloadType.Value {
+	if !s.Timeout.IsNull() && !s.TimeoutMilliseconds.IsNull() {
+		diags = append(diags, diag.NewAttributeErrorDiagnostic(path.Root("timeout"),
+			"Conflicting attributes", "Only one of timeout and timeout_milliseconds can be set."))
+	}
+	if !s.Timeout.IsNull() && !s.Timeout.IsUnknown() {
+		if _, err := parseServiceLevelTimeout(s.Timeout.Value); err != nil {
+			diags = append(diags, diag.NewAttributeErrorDiagnostic(path.Root("timeout"),
+				"Invalid duration", err.Error()))
+		}
+	}
+	if !s.TimeoutMilliseconds.IsNull() && !s.TimeoutMilliseconds.IsUnknown() && s.TimeoutMilliseconds.Value < 1 {
+		diags = append(diags, diag.NewAttributeErrorDiagnostic(path.Root("timeout_milliseconds"),
+			"Out of range", "timeout_milliseconds must be positive."))
+	}
+	if !s.WorkloadType.IsNull() && !s.WorkloadType.IsUnknown() {
+		switch strings.ToLower(s.WorkloadType.Value) {
 		case "unspecified", "interactive", "batch":
 			// ok
 		default:
@@ -108,10 +165,141 @@ func (s *serviceLevelResourceData) validate() diag.Diagnostics {
 	return diags
 }
 
+// configuredTimeout returns the timeout set by either timeout or timeout_milliseconds.
+// The returned bool is false if neither is set or the value is not known yet.
+func (s *serviceLevelResourceData) configuredTimeout() (time.Duration, bool) {
+	if !s.Timeout.IsNull() && !s.Timeout.IsUnknown() {
+		d, err := parseServiceLevelTimeout(s.Timeout.Value)
+		return d, err == nil
+	}
+	if !s.TimeoutMilliseconds.IsNull() && !s.TimeoutMilliseconds.IsUnknown() {
+		return time.Duration(s.TimeoutMilliseconds.Value) * time.Millisecond, true
+	}
+	return 0, false
+}
+
+// setTimeout sets both timeout attributes to d, or to null if d is nil.
+// The timeout attribute keeps its value if it is an equivalent duration, so that the configured form is preserved.
+func (s *serviceLevelResourceData) setTimeout(d *time.Duration) {
+	if d == nil {
+		s.Timeout = types.String{Null: true}
+		s.TimeoutMilliseconds = types.Int64{Null: true}
+		return
+	}
+	s.TimeoutMilliseconds = types.Int64{Value: d.Milliseconds()}
+	if !s.Timeout.IsNull() && !s.Timeout.IsUnknown() {
+		if current, err := parseServiceLevelTimeout(s.Timeout.Value); err == nil && current == *d {
+			return
+		}
+	}
+	s.Timeout = types.String{Value: d.String()}
+}
+
+// parseServiceLevelTimeout parses a duration such as 500ms or 2s.
+// The server stores timeouts with millisecond precision.
+func parseServiceLevelTimeout(value string) (time.Duration, error) {
+	d, err := time.ParseDuration(value)
+	if err != nil {
+		return 0, err
+	}
+	if d < time.Millisecond {
+		return 0, fmt.Errorf("timeout must be at least 1ms, got %q", value)
+	}
+	if d%time.Millisecond != 0 {
+		return 0, fmt.Errorf("timeout must be a whole number of milliseconds, got %q", value)
+	}
+	return d, nil
+}
+
+// Values of service level attributes that are not set.
+const (
+	defaultShares       = 1000
+	defaultWorkloadType = "unspecified"
+)
+
 type serviceLevelResource struct {
 	provider provider
 }
 
+func (r serviceLevelResource) UpgradeState(ctx context.Context) map[int64]tfsdk.ResourceStateUpgrader {
+	return map[int64]tfsdk.ResourceStateUpgrader{
+		0: upgradeStateAddTimeouts(),
+	}
+}
+
+func (r serviceLevelResource) ValidateConfig(ctx context.Context, req tfsdk.ValidateResourceConfigRequest, resp *tfsdk.ValidateResourceConfigResponse) {
+	var data serviceLevelResourceData
+
+	diags := req.Config.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(data.validate()...)
+}
+
+func (r serviceLevelResource) ModifyPlan(ctx context.Context, req tfsdk.ModifyResourcePlanRequest, resp *tfsdk.ModifyResourcePlanResponse) {
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var config, plan serviceLevelResourceData
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !config.Shares.IsNull() {
+		edition, diags := r.provider.clusterEdition(ctx)
+		resp.Diagnostics.Append(diags...)
+		if edition == editionOpenSource {
+			resp.Diagnostics.AddAttributeError(path.Root("shares"), "Unsupported attribute",
+				"shares is only supported by Scylla Enterprise, the cluster runs open source Scylla.")
+			return
+		}
+	}
+
+	// Derive the timeout attribute that is not configured from the one that is.
+	switch {
+	case !config.Timeout.IsNull():
+		plan.TimeoutMilliseconds = types.Int64{Unknown: true}
+	case !config.TimeoutMilliseconds.IsNull():
+		plan.Timeout = types.String{Unknown: true}
+	}
+	if d, ok := config.configuredTimeout(); ok {
+		plan.setTimeout(&d)
+	}
+
+	if !req.State.Raw.IsNull() {
+		var state serviceLevelResourceData
+
+		resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		// Attributes removed from the configuration are reset to the server defaults. The server might
+		// report the default value of shares and workload_type, so they are unknown until applied.
+		if config.Shares.IsNull() && !state.Shares.IsNull() && state.Shares.Value != defaultShares {
+			plan.Shares = types.Int64{Unknown: true}
+		}
+		if config.WorkloadType.IsNull() && !state.WorkloadType.IsNull() && !strings.EqualFold(state.WorkloadType.Value, defaultWorkloadType) {
+			plan.WorkloadType = types.String{Unknown: true}
+		}
+		if config.Timeout.IsNull() && config.TimeoutMilliseconds.IsNull() && !state.TimeoutMilliseconds.IsNull() {
+			plan.setTimeout(nil)
+		}
+	}
+
+	resp.Diagnostics.Append(resp.Plan.Set(ctx, &plan)...)
+}
+
 func (r serviceLevelResource) Create(ctx context.Context, req tfsdk.CreateResourceRequest, resp *tfsdk.CreateResourceResponse) {
 	var data serviceLevelResourceData
 
@@ -123,31 +311,71 @@ func (r serviceLevelResource) Create(ctx context.Context, req tfsdk.CreateResour
 		return
 	}
 
+	ctx, cancel, diags := withTimeout(ctx, data.Timeouts, "create")
+	defer cancel()
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	data.Id = data.Name
 
-	var stmt qb.Builder
-	stmt.Appendf("CREATE SERVICE LEVEL %s", qb.QName(data.Name.Value))
+	var options qb.OptionsBuilder
 	if !data.Shares.IsNull() && !data.Shares.IsUnknown() {
-		stmt.Once("with", " WITH ", " AND ")
-		stmt.Appendf("SHARES = %s", qb.Int(int(data.Shares.Value)))
+		options.Set("SHARES", qb.Int(int(data.Shares.Value)))
 	}
 	if !data.WorkloadType.IsNull() && !data.WorkloadType.IsUnknown() {
-		stmt.Once("with", " WITH ", " AND ")
-		stmt.Appendf("WORKLOAD_TYPE = %s", qb.String(data.WorkloadType.Value))
+		options.Set("WORKLOAD_TYPE", qb.String(strings.ToLower(data.WorkloadType.Value)))
 	}
-	if !data.TimeoutMilliseconds.IsNull() && !data.TimeoutMilliseconds.IsUnknown() {
-		stmt.Once("with", " WITH ", " AND ")
-		stmt.Appendf("TIMEOUT = %s", qb.String(fmt.Sprintf("%dms", data.TimeoutMilliseconds.Value)))
+	if timeout, ok := data.configuredTimeout(); ok {
+		options.Set("TIMEOUT", qb.String(fmt.Sprintf("%dms", timeout.Milliseconds())))
+		data.setTimeout(&timeout)
 	}
 
-	_, err := r.provider.execute(ctx, stmt.String(), nil)
+	var stmt qb.Builder
+	stmt.Append("CREATE SERVICE LEVEL ")
+	if data.AdoptExisting.Value {
+		stmt.Append("IF NOT EXISTS ")
+	}
+	stmt.Append(qb.QName(data.Name.Value))
+	stmt.Append(options.CQL())
+
+	result, err := r.provider.execute(ctx, stmt.String(), nil)
 	if err != nil {
-		resp.Diagnostics.AddError("error creating service level", err.Error())
+		resp.Diagnostics.Append(cqlErrorDiagnostic("error creating service level", stmt.String(), err))
 		return
 	}
+	resp.Diagnostics.Append(warningDiagnostics(result.Warnings)...)
+
+	if data.AdoptExisting.Value && options.Len() > 0 {
+		// The service level may have existed with other options, align the configured ones.
+		var alterStmt qb.Builder
+		alterStmt.Appendf("ALTER SERVICE LEVEL %s", qb.QName(data.Name.Value))
+		alterStmt.Append(options.CQL())
+		result, err := r.provider.execute(ctx, alterStmt.String(), nil)
+		if err != nil {
+			resp.Diagnostics.Append(cqlErrorDiagnostic("error adopting service level", alterStmt.String(), err))
+			return
+		}
+		resp.Diagnostics.Append(warningDiagnostics(result.Warnings)...)
+	}
 
 	tflog.Trace(ctx, "created service level")
 
+	exists, diags := r.readData(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !exists {
+		resp.Diagnostics.AddError("Service level not found",
+			fmt.Sprintf("Service level %q does not exist right after it was created.", data.Name.Value))
+		return
+	}
+
 	diags = resp.State.Set(ctx, &data)
 	resp.Diagnostics.Append(diags...)
 }
@@ -182,10 +410,13 @@ func (r serviceLevelResource) readData(ctx context.Context, data *serviceLevelRe
 	var stmt qb.Builder
 	stmt.Appendf("LIST SERVICE LEVEL %s", qb.QName(data.Id.Value))
 
-	result, err := r.provider.execute(ctx, stmt.String(), nil)
+	result, err := r.provider.executeAuthRead(ctx, stmt.String(), nil)
 	if err != nil {
+		if isNotFoundError(err) {
+			return false, nil
+		}
 		return false, diag.Diagnostics{
-			diag.NewErrorDiagnostic("Query error", fmt.Sprintf("Unable to read service level info: %s", err)),
+			cqlErrorDiagnostic("Query error", "Unable to read service level info.", err),
 		}
 	}
 
@@ -193,77 +424,82 @@ func (r serviceLevelResource) readData(ctx context.Context, data *serviceLevelRe
 		return false, nil
 	}
 
-	colTimeout, err := findColumn("timeout", result.ColSpec)
-	if err != nil {
-		return false, diag.Diagnostics{
-			diag.NewErrorDiagnostic("Query error", err.Error()),
-		}
+	var row struct {
+		Timeout      *time.Duration `cql:"timeout"`
+		WorkloadType *string        `cql:"workload_type"`
+		// shares is only available in Scylla Enterprise.
+		Shares *int32 `cql:"shares,optional"`
+		// Only available in Scylla Enterprise versions that report it, either as a number or as text.
+		Percentage frame.CqlValue `cql:"percentage of all service level shares,optional"`
 	}
-	valTimeout := result.Rows[0][colTimeout]
-
-	colWorkloadType, err := findColumn("workload_type", result.ColSpec)
-	if err != nil {
+	if err := scan.Row(result, 0, &row); err != nil {
 		return false, diag.Diagnostics{
 			diag.NewErrorDiagnostic("Query error", err.Error()),
 		}
 	}
-	valWorkloadType := result.Rows[0][colWorkloadType]
 
-	if valTimeout.Value == nil {
-		data.TimeoutMilliseconds = types.Int64{
-			Null: true,
-		}
+	if row.Timeout == nil {
+		data.setTimeout(nil)
 	} else {
-		timeout, err := valTimeout.AsDuration()
-		if err != nil {
-			return false, diag.Diagnostics{
-				diag.NewErrorDiagnostic("Query error", fmt.Sprintf("read timeout: %s", err.Error())),
-			}
-		}
-		// Ignore months and days from duration, timeout won't be that long.
-		data.TimeoutMilliseconds = types.Int64{
-			Value: timeout.Nanoseconds / 1e6,
-		}
+		// Months and days are ignored by scan, timeout won't be that long.
+		d := row.Timeout.Truncate(time.Millisecond)
+		data.setTimeout(&d)
 	}
 
-	if valWorkloadType.Value == nil {
+	if row.WorkloadType == nil {
 		data.WorkloadType = types.String{
 			Null: true,
 		}
-	} else {
-		workloadType, err := valWorkloadType.AsText()
-		if err != nil {
-			return false, diag.Diagnostics{
-				diag.NewErrorDiagnostic("Query error", fmt.Sprintf("read workload_type: %s", err.Error())),
-			}
-		}
+	} else if data.WorkloadType.IsNull() || data.WorkloadType.IsUnknown() || !strings.EqualFold(data.WorkloadType.Value, *row.WorkloadType) {
+		// The server stores the workload type lowercase, keep the case used in the configuration.
 		data.WorkloadType = types.String{
-			Value: workloadType,
+			Value: *row.WorkloadType,
 		}
 	}
 
 	data.Shares = types.Int64{
 		Null: true,
 	}
-	colShares, err := findColumn("shares", result.ColSpec)
-	if err == nil {
-		// shares is only available in Scylla Enterprise.
-		valShares := result.Rows[0][colShares]
-		if valShares.Value != nil {
-			shares, err := valShares.AsInt32()
-			if err != nil {
-				return false, diag.Diagnostics{
-					diag.NewErrorDiagnostic("Query error", fmt.Sprintf("read shares: %s", err.Error())),
-				}
-			}
-			data.Shares = types.Int64{
-				Value: int64(shares),
+	if row.Shares != nil {
+		data.Shares = types.Int64{
+			Value: int64(*row.Shares),
+		}
+	}
+
+	data.SharesPercentage = types.Float64{
+		Null: true,
+	}
+	if row.Percentage.Value != nil {
+		percentage, err := parseSharesPercentage(row.Percentage)
+		if err != nil {
+			return false, diag.Diagnostics{
+				diag.NewErrorDiagnostic("Query error", fmt.Sprintf("read shares percentage: %s", err.Error())),
 			}
 		}
+		data.SharesPercentage = types.Float64{
+			Value: percentage,
+		}
 	}
 	return true, nil
 }
 
+// parseSharesPercentage reads the percentage of shares, which is reported either as a number or as text such as 25.00%.
+func parseSharesPercentage(v frame.CqlValue) (float64, error) {
+	switch v.Type.ID {
+	case frame.VarcharID:
+		text, err := v.AsText()
+		if err != nil {
+			return 0, err
+		}
+		return strconv.ParseFloat(strings.TrimSpace(strings.TrimSuffix(text, "%")), 64)
+	case frame.FloatID:
+		f, err := v.AsFloat32()
+		return float64(f), err
+	default:
+		return v.AsFloat64()
+	}
+}
+
 func (r serviceLevelResource) Update(ctx context.Context, req tfsdk.UpdateResourceRequest, resp *tfsdk.UpdateResourceResponse) {
 	var plan, state serviceLevelResourceData
 
@@ -281,25 +517,48 @@ func (r serviceLevelResource) Update(ctx context.Context, req tfsdk.UpdateResour
 		return
 	}
 
-	var stmt qb.Builder
-	stmt.Appendf("ALTER SERVICE LEVEL %s", qb.QName(plan.Id.Value))
-	if !plan.Shares.Equal(state.Shares) && !plan.Shares.IsNull() && !plan.Shares.IsUnknown() {
-		stmt.Once("with", " WITH ", " AND ")
-		stmt.Appendf("SHARES = %s", qb.Int(int(plan.Shares.Value)))
+	ctx, cancel, diags := withTimeout(ctx, plan.Timeouts, "update")
+	defer cancel()
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var options qb.OptionsBuilder
+	switch {
+	case plan.Shares.IsUnknown() && !state.Shares.IsNull():
+		// Removed from the configuration.
+		options.Set("SHARES", qb.Int(defaultShares))
+	case !plan.Shares.Equal(state.Shares) && !plan.Shares.IsNull() && !plan.Shares.IsUnknown():
+		options.Set("SHARES", qb.Int(int(plan.Shares.Value)))
 	}
-	if !plan.WorkloadType.Equal(state.WorkloadType) && !plan.WorkloadType.IsNull() && !plan.WorkloadType.IsUnknown() {
-		stmt.Once("with", " WITH ", " AND ")
-		stmt.Appendf("WORKLOAD_TYPE = %s", qb.String(plan.WorkloadType.Value))
+	switch {
+	case plan.WorkloadType.IsUnknown() && !state.WorkloadType.IsNull():
+		// Removed from the configuration.
+		options.Set("WORKLOAD_TYPE", qb.String(defaultWorkloadType))
+	case !plan.WorkloadType.IsNull() && !plan.WorkloadType.IsUnknown() &&
+		(state.WorkloadType.IsNull() || !strings.EqualFold(plan.WorkloadType.Value, state.WorkloadType.Value)):
+		options.Set("WORKLOAD_TYPE", qb.String(strings.ToLower(plan.WorkloadType.Value)))
 	}
-	if !plan.TimeoutMilliseconds.Equal(state.TimeoutMilliseconds) && !plan.TimeoutMilliseconds.IsNull() && !plan.TimeoutMilliseconds.IsUnknown() {
-		stmt.Once("with", " WITH ", " AND ")
-		stmt.Appendf("TIMEOUT = %s", qb.String(fmt.Sprintf("%dms", plan.TimeoutMilliseconds.Value)))
+	if timeout, ok := plan.configuredTimeout(); ok && !plan.TimeoutMilliseconds.Equal(state.TimeoutMilliseconds) {
+		options.Set("TIMEOUT", qb.String(fmt.Sprintf("%dms", timeout.Milliseconds())))
+	} else if plan.TimeoutMilliseconds.IsNull() && !state.TimeoutMilliseconds.IsNull() {
+		options.Set("TIMEOUT", "null")
 	}
 
-	_, err := r.provider.execute(ctx, stmt.String(), nil)
-	if err != nil {
-		resp.Diagnostics.AddError("Error altering role", err.Error())
-		return
+	var stmt qb.Builder
+	stmt.Appendf("ALTER SERVICE LEVEL %s", qb.QName(plan.Id.Value))
+	stmt.Append(options.CQL())
+
+	// Nothing to alter if only the form of an attribute changed, for example the timeout from 2s to 2000ms.
+	if options.Len() > 0 {
+		result, err := r.provider.execute(ctx, stmt.String(), nil)
+		if err != nil {
+			resp.Diagnostics.Append(cqlErrorDiagnostic("Error altering service level", stmt.String(), err))
+			return
+		}
+		resp.Diagnostics.Append(warningDiagnostics(result.Warnings)...)
 	}
 
 	exists, diags := r.readData(ctx, &plan)
@@ -324,16 +583,48 @@ func (r serviceLevelResource) Delete(ctx context.Context, req tfsdk.DeleteResour
 		return
 	}
 
+	ctx, cancel, diags := withTimeout(ctx, data.Timeouts, "delete")
+	defer cancel()
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	var stmt qb.Builder
 	stmt.Appendf("DROP SERVICE LEVEL %s", qb.QName(data.Id.Value))
 
-	_, err := r.provider.execute(ctx, stmt.String(), nil)
+	result, err := r.provider.execute(ctx, stmt.String(), nil)
 	if err != nil {
-		resp.Diagnostics.AddError("Error dropping service level", err.Error())
+		resp.Diagnostics.Append(cqlErrorDiagnostic("Error dropping service level", stmt.String(), err))
 		return
 	}
+	resp.Diagnostics.Append(warningDiagnostics(result.Warnings)...)
 }
 
+// ImportState imports the service level by its name. The whole state is read, including name,
+// so that the first plan after import is empty, and service levels that do not exist are rejected.
 func (r serviceLevelResource) ImportState(ctx context.Context, req tfsdk.ImportResourceStateRequest, resp *tfsdk.ImportResourceStateResponse) {
-	tfsdk.ResourceImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	data := serviceLevelResourceData{
+		Name:          types.String{Value: req.ID},
+		Id:            types.String{Value: req.ID},
+		Timeout:       types.String{Null: true},
+		AdoptExisting: types.Bool{Null: true},
+	}
+
+	exists, diags := r.readData(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !exists {
+		resp.Diagnostics.AddError("Service level not found",
+			fmt.Sprintf("Cannot import service level %q, it does not exist.", req.ID))
+		return
+	}
+
+	diags = resp.State.Set(ctx, &data)
+	resp.Diagnostics.Append(diags...)
 }
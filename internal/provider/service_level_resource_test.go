@@ -0,0 +1,200 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/scylladb/scylla-go-driver/frame"
+	"github.com/scylladb/scylla-go-driver/transport"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseServiceLevelTimeout(t *testing.T) {
+	d, err := parseServiceLevelTimeout("1m30s")
+	assert.NoError(t, err)
+	assert.Equal(t, 90*time.Second, d)
+
+	for _, value := range []string{"", "2", "0s", "-1s", "1500us"} {
+		_, err := parseServiceLevelTimeout(value)
+		assert.Error(t, err, value)
+	}
+}
+
+func TestServiceLevelSetTimeout(t *testing.T) {
+	d := 2 * time.Second
+
+	data := serviceLevelResourceData{Timeout: types.String{Value: "2000ms"}}
+	data.setTimeout(&d)
+	assert.Equal(t, types.String{Value: "2000ms"}, data.Timeout)
+	assert.Equal(t, types.Int64{Value: 2000}, data.TimeoutMilliseconds)
+
+	data = serviceLevelResourceData{Timeout: types.String{Value: "1s"}}
+	data.setTimeout(&d)
+	assert.Equal(t, types.String{Value: "2s"}, data.Timeout)
+
+	data.setTimeout(nil)
+	assert.True(t, data.Timeout.IsNull())
+	assert.True(t, data.TimeoutMilliseconds.IsNull())
+}
+
+func TestParseSharesPercentage(t *testing.T) {
+	text, err := frame.CqlFromText("25.50%")
+	assert.NoError(t, err)
+	percentage, err := parseSharesPercentage(text)
+	assert.NoError(t, err)
+	assert.Equal(t, 25.5, percentage)
+
+	_, err = parseSharesPercentage(frame.CqlFromInt32(25))
+	assert.Error(t, err)
+}
+
+func TestServiceLevelResourceAdoptExisting(t *testing.T) {
+	cluster := newFakeCluster()
+	cluster.serviceLevels["sl"] = &fakeServiceLevel{options: map[string]string{
+		"timeout": "5000ms", "workload_type": "interactive",
+	}}
+	r := newTestResource(t, serviceLevelResourceType{}, cluster.provider())
+
+	resp := r.create(&serviceLevelResourceData{
+		Name:                types.String{Value: "sl"},
+		Id:                  types.String{Unknown: true},
+		Shares:              types.Int64{Null: true},
+		WorkloadType:        types.String{Null: true},
+		SharesPercentage:    types.Float64{Unknown: true},
+		Timeout:             types.String{Value: "2s"},
+		TimeoutMilliseconds: types.Int64{Null: true},
+		AdoptExisting:       types.Bool{Value: true},
+	})
+	require.False(t, resp.Diagnostics.HasError(), "%v", resp.Diagnostics)
+
+	assert.Equal(t, "2000ms", cluster.serviceLevels["sl"].options["timeout"])
+
+	var created serviceLevelResourceData
+	getTestState(t, resp.State, &created)
+	assert.Equal(t, "sl", created.Id.Value)
+	assert.Equal(t, int64(2000), created.TimeoutMilliseconds.Value)
+	assert.Equal(t, "interactive", created.WorkloadType.Value)
+}
+
+func TestServiceLevelResourceImportState(t *testing.T) {
+	cluster := newFakeCluster()
+	cluster.serviceLevels["sl"] = &fakeServiceLevel{options: map[string]string{"timeout": "2000ms"}}
+	r := newTestResource(t, serviceLevelResourceType{}, cluster.provider())
+
+	resp := r.importState("sl")
+	require.False(t, resp.Diagnostics.HasError(), "%v", resp.Diagnostics)
+
+	var imported serviceLevelResourceData
+	getTestState(t, resp.State, &imported)
+	assert.Equal(t, "sl", imported.Name.Value)
+	assert.Equal(t, "sl", imported.Id.Value)
+	assert.Equal(t, "2s", imported.Timeout.Value)
+	assert.Equal(t, int64(2000), imported.TimeoutMilliseconds.Value)
+
+	resp = r.importState("missing")
+	require.True(t, resp.Diagnostics.HasError())
+	assert.Equal(t, "Service level not found", resp.Diagnostics[0].Summary())
+}
+
+func TestServiceLevelResourceWorkloadTypeCase(t *testing.T) {
+	cluster := newFakeCluster()
+	r := newTestResource(t, serviceLevelResourceType{}, cluster.provider())
+
+	config := serviceLevelResourceData{
+		Name:                types.String{Value: "sl"},
+		Id:                  types.String{Unknown: true},
+		Shares:              types.Int64{Null: true},
+		WorkloadType:        types.String{Value: "Interactive"},
+		SharesPercentage:    types.Float64{Unknown: true},
+		Timeout:             types.String{Null: true},
+		TimeoutMilliseconds: types.Int64{Null: true},
+		AdoptExisting:       types.Bool{Null: true},
+	}
+	assert.False(t, config.validate().HasError())
+
+	resp := r.create(&config)
+	require.False(t, resp.Diagnostics.HasError(), "%v", resp.Diagnostics)
+	assert.Equal(t, "interactive", cluster.serviceLevels["sl"].options["workload_type"])
+
+	var created serviceLevelResourceData
+	getTestState(t, resp.State, &created)
+	assert.Equal(t, "Interactive", created.WorkloadType.Value)
+
+	// A change of case only does not alter the service level.
+	plan := created
+	plan.WorkloadType = types.String{Value: "INTERACTIVE"}
+	executed := len(cluster.executed)
+	updateResp := r.update(resp.State, &plan)
+	require.False(t, updateResp.Diagnostics.HasError(), "%v", updateResp.Diagnostics)
+	for _, stmt := range cluster.executed[executed:] {
+		assert.NotContains(t, stmt, "ALTER SERVICE LEVEL")
+	}
+
+	var updated serviceLevelResourceData
+	getTestState(t, updateResp.State, &updated)
+	assert.Equal(t, "INTERACTIVE", updated.WorkloadType.Value)
+
+	config.WorkloadType = types.String{Value: "Streaming"}
+	assert.True(t, config.validate().HasError())
+}
+
+func TestServiceLevelResourceSharesEdition(t *testing.T) {
+	config := serviceLevelResourceData{
+		Name:                types.String{Value: "sl"},
+		Id:                  types.String{Unknown: true},
+		Shares:              types.Int64{Null: true},
+		WorkloadType:        types.String{Null: true},
+		SharesPercentage:    types.Float64{Unknown: true},
+		Timeout:             types.String{Null: true},
+		TimeoutMilliseconds: types.Int64{Null: true},
+		AdoptExisting:       types.Bool{Null: true},
+	}
+	versionQuery := "SELECT version FROM system.versions WHERE key = 'local'"
+
+	cluster := newFakeCluster()
+	r := newTestResource(t, serviceLevelResourceType{}, cluster.provider())
+	empty := newEmptyTestState(r.schema)
+
+	// The edition is detected only when an attribute depending on it is configured.
+	resp := r.modifyPlan(empty, &config)
+	require.False(t, resp.Diagnostics.HasError(), "%v", resp.Diagnostics)
+	assert.NotContains(t, cluster.executed, versionQuery)
+
+	config.Shares = types.Int64{Value: 100}
+	for i := 0; i < 2; i++ {
+		resp = r.modifyPlan(empty, &config)
+		require.True(t, resp.Diagnostics.HasError())
+		assert.Equal(t, "Unsupported attribute", resp.Diagnostics[0].Summary())
+	}
+	assert.Equal(t, []string{versionQuery}, cluster.executed, "detected once")
+
+	// Failed detection is reported as a warning to every caller and the attribute is not checked.
+	p := cluster.provider()
+	unreachable := true
+	p.executor = funcExecutor(func(consistency frame.Consistency, query string,
+		values []frame.CqlValue) (transport.QueryResult, error) {
+		if unreachable {
+			return transport.QueryResult{}, fmt.Errorf("connection refused")
+		}
+		return cluster.Execute(context.Background(), consistency, query, values)
+	})
+	r = newTestResource(t, serviceLevelResourceType{}, p)
+	for i := 0; i < 2; i++ {
+		resp = r.modifyPlan(empty, &config)
+		require.False(t, resp.Diagnostics.HasError(), "%v", resp.Diagnostics)
+		require.Len(t, resp.Diagnostics, 1)
+		assert.Equal(t, diag.SeverityWarning, resp.Diagnostics[0].Severity())
+		assert.Equal(t, "Unable to detect Scylla edition", resp.Diagnostics[0].Summary())
+	}
+
+	// The failure is not cached, detection is tried again.
+	unreachable = false
+	resp = r.modifyPlan(empty, &config)
+	require.True(t, resp.Diagnostics.HasError())
+	assert.Equal(t, "Unsupported attribute", resp.Diagnostics[0].Summary())
+}
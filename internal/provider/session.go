@@ -0,0 +1,348 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/scylladb/scylla-go-driver/frame"
+	"github.com/scylladb/scylla-go-driver/transport"
+	"golang.org/x/net/proxy"
+)
+
+// CQLExecutor executes CQL statements. Resources and data sources use it through the provider,
+// so that it can be replaced in tests.
+type CQLExecutor interface {
+	// Execute runs the statement with the values bound to its markers and returns all pages of the result.
+	Execute(ctx context.Context, consistency frame.Consistency, query string, values []frame.CqlValue) (transport.QueryResult, error)
+}
+
+var _ CQLExecutor = &session{}
+
+// session executes statements on the cluster. It connects lazily on the first statement.
+// It is shared by all resources and data sources, which Terraform processes concurrently.
+type session struct {
+	// mu guards cluster and proxyConn. It is held while connecting, so that concurrent statements
+	// wait for a single connection attempt instead of each opening their own.
+	mu sync.Mutex
+
+	// cluster holds connection pools to the nodes and is used to execute the queries.
+	cluster *transport.Cluster
+
+	// policy decides which nodes the queries are sent to.
+	policy transport.HostSelectionPolicy
+
+	// hostSelection orders the contact points and the nodes of the query plans.
+	hostSelection *hostSelection
+
+	// dialer connects through a SOCKS5 proxy, if set.
+	dialer proxy.ContextDialer
+
+	// proxyConn is used instead of cluster when connecting through a proxy.
+	proxyConn *transport.Conn
+
+	// hosts is used to establish connection.
+	hosts []string
+
+	// newCluster connects to the cluster, it is replaced in tests.
+	newCluster func(ctx context.Context, cfg transport.ConnConfig, p transport.HostSelectionPolicy,
+		e []frame.EventType, hosts ...string) (*transport.Cluster, error)
+
+	// connConfig holds settings for creating connections.
+	connConfig transport.ConnConfig
+
+	// retry decides which failed statements are executed again.
+	retry retryPolicy
+
+	// keepaliveInterval is the period of heartbeats sent over idle connections, zero disables them.
+	keepaliveInterval time.Duration
+
+	// stopKeepalive is closed to stop the heartbeats of the cluster or the proxy connection once it is closed.
+	stopKeepalive chan struct{}
+
+	// schemaAgreementTimeout limits waiting for schema agreement after schema-altering statements.
+	schemaAgreementTimeout time.Duration
+
+	// writeSlots limits the number of concurrently executed statements that modify the cluster.
+	writeSlots chan struct{}
+
+	// readOnly refuses execution of statements that could modify the cluster.
+	readOnly bool
+
+	// pageSize is the number of rows fetched at once.
+	pageSize int32
+}
+
+// initCluster connects to the cluster unless it is already connected.
+// The cluster keeps a pool of connections to every node and refreshes the topology in the background,
+// so it is created with a context that outlives the request.
+func (s *session) initCluster(ctx context.Context) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.cluster != nil || s.proxyConn != nil {
+		return nil
+	}
+
+	// Connections outlive the request that opens them, so they are opened in the background
+	// and only waiting for them is aborted when ctx is done.
+	type connected struct {
+		cluster *transport.Cluster
+		conn    *transport.Conn
+		err     error
+	}
+	done := make(chan connected, 1)
+	go func() {
+		if s.dialer != nil {
+			conn, err := s.openProxyConn()
+			done <- connected{conn: conn, err: err}
+			return
+		}
+		newCluster := s.newCluster
+		if newCluster == nil {
+			newCluster = transport.NewCluster
+		}
+		cluster, err := newCluster(context.Background(), s.connConfig, s.policy,
+			[]frame.EventType{frame.TopologyChange, frame.StatusChange}, s.hostSelection.orderHosts(s.hosts)...)
+		if err != nil {
+			// The driver reports the errors of the hosts as text only, probe them again
+			// to report the error of every host in a way that can be classified.
+			if errs := s.probeHosts(context.Background()); len(errs) == len(s.hosts) {
+				err = errs
+			}
+		}
+		done <- connected{cluster: cluster, err: err}
+	}()
+
+	var c connected
+	select {
+	case c = <-done:
+	case <-ctx.Done():
+		go func() {
+			c := <-done
+			if c.cluster != nil {
+				c.cluster.Close()
+			}
+			if c.conn != nil {
+				c.conn.Close()
+			}
+		}()
+		return ctx.Err()
+	}
+	if c.err != nil {
+		return c.err
+	}
+
+	if c.conn != nil {
+		s.proxyConn = c.conn
+		if s.keepaliveInterval > 0 {
+			s.stopKeepalive = make(chan struct{})
+			go keepaliveConn(c.conn, s.keepaliveInterval, s.stopKeepalive)
+		}
+		return nil
+	}
+	s.cluster = c.cluster
+	if s.keepaliveInterval > 0 {
+		s.stopKeepalive = make(chan struct{})
+		go keepaliveCluster(c.cluster, s.keepaliveInterval, s.stopKeepalive)
+	}
+	return nil
+}
+
+// close closes the cluster or the proxy connection and stops their heartbeats.
+func (s *session) close() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.cluster != nil {
+		s.cluster.Close()
+		s.cluster = nil
+	}
+	if s.proxyConn != nil {
+		s.proxyConn.Close()
+		s.proxyConn = nil
+	}
+	s.stopKeepaliveLocked()
+}
+
+// stopKeepaliveLocked stops the heartbeats of the closed cluster or proxy connection. s.mu must be held.
+func (s *session) stopKeepaliveLocked() {
+	if s.stopKeepalive != nil {
+		close(s.stopKeepalive)
+		s.stopKeepalive = nil
+	}
+}
+
+// conn returns a connection to the first available node of the query plan.
+func (s *session) conn() (*transport.Conn, error) {
+	s.mu.Lock()
+	cluster, proxyConn := s.cluster, s.proxyConn
+	s.mu.Unlock()
+
+	if proxyConn != nil {
+		return proxyConn, nil
+	}
+	if cluster == nil {
+		return nil, fmt.Errorf("not connected")
+	}
+	info := cluster.NewQueryInfo()
+	var nodes []*transport.Node
+	for i := 0; ; i++ {
+		n := s.policy.Node(info, i)
+		if n == nil {
+			break
+		}
+		nodes = append(nodes, n)
+	}
+	var lastErr error
+	for _, n := range s.hostSelection.orderNodes(nodes, s.hosts) {
+		conn, err := n.Conn(info)
+		if err != nil {
+			lastErr = err
+			continue
+		}
+		return conn, nil
+	}
+	if lastErr == nil {
+		lastErr = fmt.Errorf("no node is available")
+	}
+	return nil, lastErr
+}
+
+func (s *session) Execute(ctx context.Context, consistency frame.Consistency, query string,
+	values []frame.CqlValue) (transport.QueryResult, error) {
+	if s.readOnly && !isReadStatement(query) {
+		return transport.QueryResult{}, fmt.Errorf("the provider is in read-only mode, refusing to execute:\n%s", query)
+	}
+	frameValues := make([]frame.Value, len(values))
+	for i := range values {
+		frameValues[i].N = frame.Int(len(values[i].Value))
+		frameValues[i].Bytes = values[i].Value
+	}
+	stmt := transport.Statement{
+		Content:           query,
+		Values:            frameValues,
+		PageSize:          s.pageSize,
+		Consistency:       consistency,
+		SerialConsistency: serialConsistencyFrom(ctx),
+	}
+
+	if s.writeSlots != nil && !isReadStatement(query) {
+		select {
+		case s.writeSlots <- struct{}{}:
+			defer func() { <-s.writeSlots }()
+		case <-ctx.Done():
+			return transport.QueryResult{}, ctx.Err()
+		}
+	}
+
+	idempotent := isIdempotent(query)
+
+	ctx = withCQLLog(ctx)
+	ctx = tflog.SubsystemSetField(ctx, cqlLogSubsystem, "statement", redactStatement(query))
+	start := time.Now()
+
+	var result transport.QueryResult
+	var pagingState frame.Bytes
+	for page := 1; ; page++ {
+		pageCtx := tflog.SubsystemSetField(ctx, cqlLogSubsystem, "page", page)
+		pageResult, err := s.queryWithRetry(pageCtx, stmt, pagingState, idempotent)
+		if err != nil {
+			tflog.SubsystemDebug(ctx, cqlLogSubsystem, "Statement failed", map[string]interface{}{
+				"consistency": consistencyName(consistency),
+				"latency":     time.Since(start).String(),
+				"error":       err.Error(),
+			})
+			return transport.QueryResult{}, err
+		}
+		if page == 1 {
+			result = pageResult
+		} else {
+			result.Rows = append(result.Rows, pageResult.Rows...)
+			result.Warnings = append(result.Warnings, pageResult.Warnings...)
+		}
+		if !pageResult.HasMorePages {
+			tflog.SubsystemDebug(ctx, cqlLogSubsystem, "Executed statement", map[string]interface{}{
+				"consistency": consistencyName(consistency),
+				"latency":     time.Since(start).String(),
+				"rows":        len(result.Rows),
+				"pages":       page,
+				"warnings":    len(result.Warnings),
+			})
+			break
+		}
+		pagingState = pageResult.PagingState
+	}
+	result.HasMorePages = false
+	result.PagingState = nil
+
+	if result.SchemaChange != nil {
+		s.awaitSchemaAgreement(ctx)
+	}
+	return result, nil
+}
+
+// queryWithRetry fetches a single page of the statement result, retrying according to s.retry.
+func (s *session) queryWithRetry(ctx context.Context, stmt transport.Statement, pagingState frame.Bytes,
+	idempotent bool) (transport.QueryResult, error) {
+	for attempt := 1; ; attempt++ {
+		result, err := s.query(ctx, stmt, pagingState)
+		if err == nil || !s.retry.shouldRetry(attempt, err, idempotent) {
+			return result, err
+		}
+
+		backoff := s.retry.backoff(attempt)
+		tflog.Debug(ctx, "retrying statement", map[string]interface{}{
+			"attempt": attempt,
+			"backoff": backoff.String(),
+			"error":   err.Error(),
+		})
+
+		timer := time.NewTimer(backoff)
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+			return transport.QueryResult{}, ctx.Err()
+		}
+	}
+}
+
+// query executes stmt on a connection to the first available node, connecting first if needed.
+func (s *session) query(ctx context.Context, stmt transport.Statement, pagingState frame.Bytes) (transport.QueryResult, error) {
+	if err := s.initCluster(ctx); err != nil {
+		return transport.QueryResult{}, err
+	}
+	conn, err := s.conn()
+	if err != nil {
+		return transport.QueryResult{}, err
+	}
+	start := time.Now()
+	result, err := conn.Query(ctx, stmt, pagingState)
+	fields := map[string]interface{}{
+		"host":           conn.RemoteAddr().String(),
+		"latency":        time.Since(start).String(),
+		"paging_state":   pagingState != nil,
+		"rows":           len(result.Rows),
+		"has_more_pages": result.HasMorePages,
+	}
+	if err != nil {
+		fields["error"] = err.Error()
+	}
+	tflog.SubsystemTrace(ctx, cqlLogSubsystem, "Executed statement page", fields)
+	if err != nil && isConnectionError(err) {
+		// Connection pools of the cluster are refilled by the driver,
+		// the proxy connection is reopened by the next statement.
+		s.mu.Lock()
+		if conn == s.proxyConn {
+			tflog.Warn(ctx, "Connection broken, reconnecting", map[string]interface{}{"error": err.Error()})
+			conn.Close()
+			s.proxyConn = nil
+			s.stopKeepaliveLocked()
+		}
+		s.mu.Unlock()
+	}
+	return result, err
+}
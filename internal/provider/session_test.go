@@ -0,0 +1,51 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/scylladb/scylla-go-driver/frame"
+	"github.com/scylladb/scylla-go-driver/transport"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// funcExecutor is a CQLExecutor calling a function for every statement.
+type funcExecutor func(consistency frame.Consistency, query string, values []frame.CqlValue) (transport.QueryResult, error)
+
+func (f funcExecutor) Execute(ctx context.Context, consistency frame.Consistency, query string,
+	values []frame.CqlValue) (transport.QueryResult, error) {
+	return f(consistency, query, values)
+}
+
+func TestProviderExecutor(t *testing.T) {
+	var executed []string
+	p := provider{
+		consistency:     frame.ONE,
+		authConsistency: frame.LOCALQUORUM,
+		executor: funcExecutor(func(consistency frame.Consistency, query string, values []frame.CqlValue) (transport.QueryResult, error) {
+			executed = append(executed, query)
+			if consistency != frame.LOCALQUORUM {
+				return transport.QueryResult{}, nil
+			}
+			name, err := values[0].AsText()
+			require.NoError(t, err)
+			if name != "ks" {
+				return transport.QueryResult{}, nil
+			}
+			return transport.QueryResult{Rows: []frame.Row{{values[0]}}}, nil
+		}),
+	}
+
+	exists, err := p.exists(context.Background(), "SELECT keyspace_name FROM system_schema.keyspaces WHERE keyspace_name = ?", "ks")
+	require.NoError(t, err)
+	assert.True(t, exists)
+
+	exists, err = p.exists(context.Background(), "SELECT keyspace_name FROM system_schema.keyspaces WHERE keyspace_name = ?", "other")
+	require.NoError(t, err)
+	assert.False(t, exists)
+
+	_, err = p.execute(context.Background(), "DROP KEYSPACE ks", nil)
+	require.NoError(t, err)
+	assert.Len(t, executed, 3)
+}
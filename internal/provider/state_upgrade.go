@@ -0,0 +1,42 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+)
+
+// Resources version their schemas. When a change of a schema needs existing states to be converted,
+// for example when an attribute is renamed, the version is incremented and the resource's UpgradeState
+// gets an upgrader from the previous version, so that users do not have to edit their states.
+
+// upgradeStateAddTimeouts upgrades a state of version 0 to version 1, which added the timeouts block.
+// States written with the block are accepted too.
+func upgradeStateAddTimeouts() tfsdk.ResourceStateUpgrader {
+	return tfsdk.ResourceStateUpgrader{
+		StateUpgrader: func(ctx context.Context, req tfsdk.UpgradeResourceStateRequest, resp *tfsdk.UpgradeResourceStateResponse) {
+			if req.RawState == nil || req.RawState.JSON == nil {
+				resp.Diagnostics.AddError("Unable to upgrade state",
+					"The state has no JSON representation. It was likely last written by Terraform 0.11 or older.")
+				return
+			}
+
+			var attributes map[string]json.RawMessage
+			if err := json.Unmarshal(req.RawState.JSON, &attributes); err != nil {
+				resp.Diagnostics.AddError("Unable to upgrade state", err.Error())
+				return
+			}
+			if _, ok := attributes["timeouts"]; !ok {
+				attributes["timeouts"] = json.RawMessage("null")
+			}
+			upgraded, err := json.Marshal(attributes)
+			if err != nil {
+				resp.Diagnostics.AddError("Unable to upgrade state", err.Error())
+				return
+			}
+			resp.DynamicValue = &tfprotov6.DynamicValue{JSON: upgraded}
+		},
+	}
+}
@@ -0,0 +1,43 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUpgradeStateAddTimeouts(t *testing.T) {
+	ctx := context.Background()
+	server, err := testAccProtoV6ProviderFactories["scylla"]()
+	require.NoError(t, err)
+
+	for _, state := range []string{
+		`{"grantee":"app","id":"app/shop/SELECT","keyspace":"shop","permission":"SELECT"}`,
+		`{"grantee":"app","id":"app/shop/SELECT","keyspace":"shop","permission":"SELECT","timeouts":[]}`,
+	} {
+		resp, err := server.UpgradeResourceState(ctx, &tfprotov6.UpgradeResourceStateRequest{
+			TypeName: "scylla_keyspace_grant",
+			Version:  0,
+			RawState: &tfprotov6.RawState{JSON: []byte(state)},
+		})
+		require.NoError(t, err)
+		require.Empty(t, resp.Diagnostics, state)
+
+		schemaResp, err := server.GetProviderSchema(ctx, &tfprotov6.GetProviderSchemaRequest{})
+		require.NoError(t, err)
+		schema := schemaResp.ResourceSchemas["scylla_keyspace_grant"]
+		assert.Equal(t, int64(1), schema.Version)
+
+		value, err := resp.UpgradedState.Unmarshal(schema.ValueType())
+		require.NoError(t, err)
+		var attributes map[string]tftypes.Value
+		require.NoError(t, value.As(&attributes))
+		var keyspace string
+		require.NoError(t, attributes["keyspace"].As(&keyspace))
+		assert.Equal(t, "shop", keyspace)
+	}
+}
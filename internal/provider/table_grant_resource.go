@@ -3,7 +3,6 @@ package provider
 import (
 	"context"
 	"fmt"
-	"sort"
 	"strings"
 
 	"github.com/hashicorp/terraform-plugin-framework/diag"
@@ -17,37 +16,58 @@ import (
 // Ensure provider defined types fully satisfy framework interfaces
 var _ tfsdk.ResourceType = tableGrantResourceType{}
 var _ tfsdk.Resource = tableGrantResource{}
+var _ tfsdk.ResourceWithUpgradeState = tableGrantResource{}
 var _ tfsdk.ResourceWithImportState = tableGrantResource{}
+var _ tfsdk.ResourceWithValidateConfig = tableGrantResource{}
+var _ tfsdk.ResourceWithModifyPlan = tableGrantResource{}
 var _ grantResourceData = &tableGrantResourceData{}
+var _ grantTargetData = &tableGrantResourceData{}
 
 type tableGrantResourceType struct{}
 
 func (t tableGrantResourceType) GetSchema(ctx context.Context) (tfsdk.Schema, diag.Diagnostics) {
 	return tfsdk.Schema{
+		// Version 1 added the timeouts block.
+		Version: 1,
+
 		// This description is used by the documentation generator and the language server.
 		MarkdownDescription: "Manages grant to a single table for a single role",
 
 		Attributes: map[string]tfsdk.Attribute{
 			"keyspace": {
-				MarkdownDescription: "Name of the keyspace where the table resides",
+				MarkdownDescription: "Name of the keyspace where the table resides. " + identifierDescription,
 				Required:            true,
 				Type:                types.StringType,
+				Validators: []tfsdk.AttributeValidator{
+					keyspaceNameValidator(),
+				},
 				PlanModifiers: []tfsdk.AttributePlanModifier{
-					tfsdk.RequiresReplace(),
+					requiresReplaceIfNameChanged(),
 				},
 			},
 			"table": {
-				MarkdownDescription: "Name of the table",
+				MarkdownDescription: "Name of the table. " + identifierDescription,
 				Required:            true,
 				Type:                types.StringType,
+				Validators: []tfsdk.AttributeValidator{
+					tableNameValidator(),
+				},
 				PlanModifiers: []tfsdk.AttributePlanModifier{
-					tfsdk.RequiresReplace(),
+					requiresReplaceIfNameChanged(),
 				},
 			},
+			"id": {
+				Computed:            true,
+				MarkdownDescription: "ID of the grant in the form `grantee/keyspace/table/permission`",
+				Type:                types.StringType,
+			},
 			"grantee": {
 				Required:            true,
 				MarkdownDescription: "The name of the role that will be granted privileges to the resource.",
 				Type:                types.StringType,
+				Validators: []tfsdk.AttributeValidator{
+					roleNameValidator(),
+				},
 				PlanModifiers: []tfsdk.AttributePlanModifier{
 					tfsdk.RequiresReplace(),
 				},
@@ -64,11 +84,11 @@ One of:
 * SELECT
 `,
 				Type: types.StringType,
-				PlanModifiers: []tfsdk.AttributePlanModifier{
-					tfsdk.RequiresReplace(),
-				},
 			},
 		},
+		Blocks: map[string]tfsdk.Block{
+			"timeouts": timeoutsBlock(),
+		},
 	}, nil
 }
 
@@ -84,16 +104,18 @@ type tableGrantResourceData struct {
 	Keyspace   types.String `tfsdk:"keyspace"`
 	Table      types.String `tfsdk:"table"`
 	Grantee    types.String `tfsdk:"grantee"`
+	Id         types.String `tfsdk:"id"`
 	Permission types.String `tfsdk:"permission"`
+
+	Timeouts []timeoutsData `tfsdk:"timeouts"`
 }
 
 func (t *tableGrantResourceData) resource() qb.CQL {
-	return qb.CQL(fmt.Sprintf("%s.%s", qb.QName(t.Keyspace.Value), qb.QName(t.Table.Value)))
+	return qb.CQL(fmt.Sprintf("%s.%s", cqlName(t.Keyspace.Value), cqlName(t.Table.Value)))
 }
 
 func (t *tableGrantResourceData) listResource() string {
-	return fmt.Sprintf("<table %s.%s>", strings.ToLower(t.Keyspace.Value),
-		strings.ToLower(t.Table.Value))
+	return fmt.Sprintf("<table %s.%s>", qb.NormalizeName(t.Keyspace.Value), qb.NormalizeName(t.Table.Value))
 }
 
 func (t *tableGrantResourceData) permission() qb.CQL {
@@ -104,16 +126,29 @@ func (t *tableGrantResourceData) grantee() string {
 	return t.Grantee.Value
 }
 
+func (t *tableGrantResourceData) target() (keyspace, table string) {
+	return qb.NormalizeName(t.Keyspace.Value), qb.NormalizeName(t.Table.Value)
+}
+
+func (t *tableGrantResourceData) timeouts() []timeoutsData {
+	return t.Timeouts
+}
+
+func (t *tableGrantResourceData) setId() {
+	t.Id = types.String{Value: fmt.Sprintf("%s/%s/%s/%s", t.Grantee.Value, grantIdName(t.Keyspace.Value), grantIdName(t.Table.Value),
+		strings.ToUpper(t.Permission.Value))}
+}
+
 func (t *tableGrantResourceData) validate() (diags diag.Diagnostics) {
-	if t.Keyspace.IsNull() || t.Keyspace.IsUnknown() || t.Keyspace.Value == "" {
+	if t.Keyspace.IsNull() || t.Keyspace.IsUnknown() {
 		diags.AddAttributeError(path.Root("keyspace"), "Keyspace missing",
 			"Keyspace of the table must be specified.")
 	}
-	if t.Table.IsNull() || t.Table.IsUnknown() || t.Table.Value == "" {
+	if t.Table.IsNull() || t.Table.IsUnknown() {
 		diags.AddAttributeError(path.Root("table"), "Table missing",
 			"Table name must be specified.")
 	}
-	if t.Grantee.IsNull() || t.Grantee.IsUnknown() || t.Grantee.Value == "" {
+	if t.Grantee.IsNull() || t.Grantee.IsUnknown() {
 		diags.AddAttributeError(path.Root("grantee"), "Grantee missing",
 			"Grantee must be specified.")
 	}
@@ -121,16 +156,7 @@ func (t *tableGrantResourceData) validate() (diags diag.Diagnostics) {
 		diags.AddAttributeError(path.Root("permission"), "Permission missing",
 			"Permission must be specified.")
 	} else {
-		perm := strings.ToUpper(t.Permission.Value)
-		permNames := make([]string, 0, len(tablePermissions))
-		for k := range tablePermissions {
-			permNames = append(permNames, k)
-		}
-		sort.Strings(permNames)
-		if _, ok := tablePermissions[perm]; !ok {
-			diags.AddAttributeError(path.Root("permission"), "Unsupported permission",
-				fmt.Sprintf("Permission must be one of %s", permNames))
-		}
+		diags.Append(validatePermission(path.Root("permission"), t.Permission, tablePermissions, "a table")...)
 	}
 
 	return
@@ -140,14 +166,55 @@ type tableGrantResource struct {
 	provider provider
 }
 
+func (r tableGrantResource) UpgradeState(ctx context.Context) map[int64]tfsdk.ResourceStateUpgrader {
+	return map[int64]tfsdk.ResourceStateUpgrader{
+		0: upgradeStateAddTimeouts(),
+	}
+}
+
 var tablePermissions = map[string]struct{}{
-	"CREATE":    {},
 	"ALTER":     {},
 	"DROP":      {},
 	"SELECT":    {},
 	"MODIFY":    {},
 	"AUTHORIZE": {},
-	"DESCRIBE":  {},
+}
+
+func (r tableGrantResource) ValidateConfig(ctx context.Context, req tfsdk.ValidateResourceConfigRequest, resp *tfsdk.ValidateResourceConfigResponse) {
+	var data tableGrantResourceData
+
+	diags := req.Config.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(validatePermission(path.Root("permission"), data.Permission, tablePermissions, "a table")...)
+}
+
+// ModifyPlan warns about grants on tables that do not exist when validate_targets is set,
+// so that typos in names are noticed during plan rather than by a failed apply.
+func (r tableGrantResource) ModifyPlan(ctx context.Context, req tfsdk.ModifyResourcePlanRequest, resp *tfsdk.ModifyResourcePlanResponse) {
+	if req.Plan.Raw.IsNull() || !r.provider.configured || !r.provider.validateTargets {
+		return
+	}
+
+	var plan tableGrantResourceData
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+
+	if resp.Diagnostics.HasError() || plan.Keyspace.IsUnknown() || plan.Table.IsUnknown() {
+		return
+	}
+
+	keyspace, table := plan.target()
+	exists, err := r.provider.cachedTargetExists(ctx, keyspace, table)
+	if err != nil {
+		resp.Diagnostics.AddWarning("Unable to check table",
+			fmt.Sprintf("Unable to check that table %q exists in keyspace %q: %s", table, keyspace, err))
+		return
+	}
+	if !exists {
+		resp.Diagnostics.AddAttributeWarning(path.Root("table"), "Table does not exist",
+			fmt.Sprintf("Table %q does not exist in keyspace %q. Granting permissions on it will fail "+
+				"unless it is created before the grant is applied.", table, keyspace))
+	}
 }
 
 func (r tableGrantResource) Create(ctx context.Context, req tfsdk.CreateResourceRequest, resp *tfsdk.CreateResourceResponse) {
@@ -161,7 +228,8 @@ func (r tableGrantResource) Read(ctx context.Context, req tfsdk.ReadResourceRequ
 }
 
 func (r tableGrantResource) Update(ctx context.Context, req tfsdk.UpdateResourceRequest, resp *tfsdk.UpdateResourceResponse) {
-	resp.Diagnostics.AddError("Update not supported", "Grant resource does not support update, only recreate")
+	var plan, state tableGrantResourceData
+	r.provider.updateGrant(ctx, req, resp, &plan, &state)
 }
 
 func (r tableGrantResource) Delete(ctx context.Context, req tfsdk.DeleteResourceRequest, resp *tfsdk.DeleteResourceResponse) {
@@ -170,5 +238,5 @@ func (r tableGrantResource) Delete(ctx context.Context, req tfsdk.DeleteResource
 }
 
 func (r tableGrantResource) ImportState(ctx context.Context, req tfsdk.ImportResourceStateRequest, resp *tfsdk.ImportResourceStateResponse) {
-	tfsdk.ResourceImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	importGrant(ctx, req, resp, "grantee", "keyspace", "table", "permission")
 }
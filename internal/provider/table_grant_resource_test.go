@@ -0,0 +1,80 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTableGrantResourceModifyPlanWarnsMissingTable(t *testing.T) {
+	cluster := newFakeCluster()
+	cluster.tables["shop.users"] = struct{}{}
+	p := cluster.provider()
+	p.validateTargets = true
+	r := newTestResource(t, tableGrantResourceType{}, p)
+
+	executed := len(cluster.executed)
+	for table, warn := range map[string]bool{"users": false, "Users": false, "usres": true, `"Users"`: true} {
+		resp := r.modifyPlan(newEmptyTestState(r.schema), &tableGrantResourceData{
+			Keyspace:   types.String{Value: "shop"},
+			Table:      types.String{Value: table},
+			Grantee:    types.String{Value: "app"},
+			Id:         types.String{Unknown: true},
+			Permission: types.String{Value: "SELECT"},
+		})
+		assert.False(t, resp.Diagnostics.HasError(), "%v", resp.Diagnostics)
+		assert.Equal(t, warn, resp.Diagnostics.WarningsCount() > 0, table)
+		if warn {
+			assert.Equal(t, diag.SeverityWarning, resp.Diagnostics[0].Severity())
+		}
+	}
+	// The schema is listed once for the plans of all grants.
+	assert.Equal(t, []string{
+		"SELECT keyspace_name FROM system_schema.keyspaces",
+		"SELECT keyspace_name, table_name FROM system_schema.tables",
+	}, cluster.executed[executed:])
+}
+
+func TestTableGrantResourceId(t *testing.T) {
+	cluster := newFakeCluster()
+	cluster.addRole("app")
+	cluster.tables["shop.users"] = struct{}{}
+	cluster.tables["shop.Users"] = struct{}{}
+	r := newTestResource(t, tableGrantResourceType{}, cluster.provider())
+
+	tests := []struct {
+		keyspace, table string
+		id              string
+	}{
+		{"shop", "users", "app/shop/users/SELECT"},
+		{`"shop"`, "Users", "app/shop/users/SELECT"},
+		{"Shop", `"users"`, "app/shop/users/SELECT"},
+		{"shop", `"Users"`, `app/shop/"Users"/SELECT`},
+	}
+	for _, test := range tests {
+		createResp := r.create(&tableGrantResourceData{
+			Keyspace:   types.String{Value: test.keyspace},
+			Table:      types.String{Value: test.table},
+			Grantee:    types.String{Value: "app"},
+			Id:         types.String{Unknown: true},
+			Permission: types.String{Value: "select"},
+		})
+		require.False(t, createResp.Diagnostics.HasError(), "%v", createResp.Diagnostics)
+		var created tableGrantResourceData
+		getTestState(t, createResp.State, &created)
+		assert.Equal(t, test.id, created.Id.Value, "%s.%s", test.keyspace, test.table)
+
+		// Importing the ID refers to the same table.
+		importResp := r.importState(test.id)
+		require.False(t, importResp.Diagnostics.HasError(), "%v", importResp.Diagnostics)
+		readResp := r.read(importResp.State)
+		require.False(t, readResp.Diagnostics.HasError(), "%v", readResp.Diagnostics)
+		require.False(t, readResp.State.Raw.IsNull(), test.id)
+		var imported tableGrantResourceData
+		getTestState(t, readResp.State, &imported)
+		assert.Equal(t, test.id, imported.Id.Value)
+	}
+}
@@ -0,0 +1,74 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// timeoutsData is the timeouts block of resources, it limits the duration of their operations.
+type timeoutsData struct {
+	Create types.String `tfsdk:"create"`
+	Update types.String `tfsdk:"update"`
+	Delete types.String `tfsdk:"delete"`
+}
+
+// timeoutsBlock returns the schema of the timeouts block of resources.
+func timeoutsBlock() tfsdk.Block {
+	attribute := func(operation string) tfsdk.Attribute {
+		return tfsdk.Attribute{
+			MarkdownDescription: fmt.Sprintf("Maximum duration of %s of the resource, for example `5m`, "+
+				"including retries and waiting for schema agreement. Not limited by default.", operation),
+			Optional: true,
+			Type:     types.StringType,
+		}
+	}
+	return tfsdk.Block{
+		MarkdownDescription: "Timeouts of operations on the resource.",
+		NestingMode:         tfsdk.BlockNestingModeList,
+		MaxItems:            1,
+		Attributes: map[string]tfsdk.Attribute{
+			"create": attribute("creation"),
+			"update": attribute("update"),
+			"delete": attribute("deletion"),
+		},
+	}
+}
+
+// withTimeout returns ctx limited by the timeout configured for the operation, one of create, update and delete.
+// The returned cancel function must be called once the operation finishes.
+func withTimeout(ctx context.Context, timeouts []timeoutsData, operation string) (context.Context, context.CancelFunc, diag.Diagnostics) {
+	if len(timeouts) == 0 {
+		return ctx, func() {}, nil
+	}
+
+	var timeout types.String
+	switch operation {
+	case "create":
+		timeout = timeouts[0].Create
+	case "update":
+		timeout = timeouts[0].Update
+	case "delete":
+		timeout = timeouts[0].Delete
+	default:
+		panic("unknown operation " + operation)
+	}
+	if timeout.IsNull() || timeout.IsUnknown() {
+		return ctx, func() {}, nil
+	}
+
+	var diags diag.Diagnostics
+	d, err := time.ParseDuration(timeout.Value)
+	if err != nil || d <= 0 {
+		diags.AddAttributeError(path.Root("timeouts").AtListIndex(0).AtName(operation), "Invalid timeout",
+			fmt.Sprintf("The timeout must be a positive duration such as 30s or 5m, got %q.", timeout.Value))
+		return ctx, func() {}, diags
+	}
+	ctx, cancel := context.WithTimeout(ctx, d)
+	return ctx, cancel, diags
+}
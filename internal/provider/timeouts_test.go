@@ -0,0 +1,44 @@
+package provider
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithTimeout(t *testing.T) {
+	ctx := context.Background()
+
+	got, cancel, diags := withTimeout(ctx, nil, "create")
+	defer cancel()
+	require.False(t, diags.HasError())
+	_, ok := got.Deadline()
+	assert.False(t, ok)
+
+	timeouts := []timeoutsData{{
+		Create: types.String{Value: "2m"},
+		Update: types.String{Null: true},
+		Delete: types.String{Value: "soon"},
+	}}
+
+	got, cancel, diags = withTimeout(ctx, timeouts, "create")
+	defer cancel()
+	require.False(t, diags.HasError())
+	deadline, ok := got.Deadline()
+	require.True(t, ok)
+	assert.WithinDuration(t, time.Now().Add(2*time.Minute), deadline, time.Second)
+
+	got, cancel, diags = withTimeout(ctx, timeouts, "update")
+	defer cancel()
+	require.False(t, diags.HasError())
+	_, ok = got.Deadline()
+	assert.False(t, ok)
+
+	_, cancel, diags = withTimeout(ctx, timeouts, "delete")
+	defer cancel()
+	assert.True(t, diags.HasError())
+}
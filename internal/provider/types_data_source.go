@@ -0,0 +1,156 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/kiwicom/terraform-provider-scylla/internal/scan"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces
+var _ tfsdk.DataSourceType = typesDataSourceType{}
+var _ tfsdk.DataSource = typesDataSource{}
+
+type typesDataSourceType struct{}
+
+func (t typesDataSourceType) GetSchema(ctx context.Context) (tfsdk.Schema, diag.Diagnostics) {
+	return tfsdk.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Lists user-defined types in a keyspace",
+
+		Attributes: map[string]tfsdk.Attribute{
+			"keyspace": {
+				MarkdownDescription: "Name of the keyspace",
+				Required:            true,
+				Type:                types.StringType,
+			},
+			"id": {
+				MarkdownDescription: "Identifier of the data source",
+				Computed:            true,
+				Type:                types.StringType,
+			},
+			"types": {
+				MarkdownDescription: "User-defined types in the keyspace.",
+				Computed:            true,
+				Attributes: tfsdk.ListNestedAttributes(map[string]tfsdk.Attribute{
+					"name": {
+						MarkdownDescription: "Name of the type",
+						Computed:            true,
+						Type:                types.StringType,
+					},
+					"fields": {
+						MarkdownDescription: "Fields of the type in declaration order.",
+						Computed:            true,
+						Attributes: tfsdk.ListNestedAttributes(map[string]tfsdk.Attribute{
+							"name": {
+								MarkdownDescription: "Name of the field",
+								Computed:            true,
+								Type:                types.StringType,
+							},
+							"type": {
+								MarkdownDescription: "CQL type of the field, for example `frozen<list<text>>`.",
+								Computed:            true,
+								Type:                types.StringType,
+							},
+						}),
+					},
+				}),
+			},
+		},
+	}, nil
+}
+
+func (t typesDataSourceType) NewDataSource(ctx context.Context, in tfsdk.Provider) (tfsdk.DataSource, diag.Diagnostics) {
+	provider, diags := convertProviderType(in)
+
+	return typesDataSource{
+		provider: provider,
+	}, diags
+}
+
+type typesDataSourceData struct {
+	Keyspace types.String              `tfsdk:"keyspace"`
+	Id       types.String              `tfsdk:"id"`
+	Types    []typesDataSourceTypeData `tfsdk:"types"`
+}
+
+type typesDataSourceTypeData struct {
+	Name   types.String               `tfsdk:"name"`
+	Fields []typesDataSourceFieldData `tfsdk:"fields"`
+}
+
+type typesDataSourceFieldData struct {
+	Name types.String `tfsdk:"name"`
+	Type types.String `tfsdk:"type"`
+}
+
+type typesDataSource struct {
+	provider provider
+}
+
+func (d typesDataSource) Read(ctx context.Context, req tfsdk.ReadDataSourceRequest, resp *tfsdk.ReadDataSourceResponse) {
+	var data typesDataSourceData
+
+	diags := req.Config.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	values, err := bind(data.Keyspace.Value)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("keyspace"), "Cannot convert keyspace name", err.Error())
+		return
+	}
+
+	result, err := d.provider.execute(ctx,
+		"SELECT type_name, field_names, field_types FROM system_schema.types WHERE keyspace_name = ?",
+		values)
+	if err != nil {
+		resp.Diagnostics.Append(cqlErrorDiagnostic("Query error", "Unable to read types.", err))
+		return
+	}
+
+	var rows []struct {
+		Name       string   `cql:"type_name"`
+		FieldNames []string `cql:"field_names"`
+		FieldTypes []string `cql:"field_types"`
+	}
+	if err := scan.Rows(result, &rows); err != nil {
+		resp.Diagnostics.AddError("Query result error", fmt.Sprintf("Unable to read types: %s", err))
+		return
+	}
+
+	data.Types = make([]typesDataSourceTypeData, 0, len(rows))
+	for _, row := range rows {
+		name, fieldNames, fieldTypes := row.Name, row.FieldNames, row.FieldTypes
+		if len(fieldNames) != len(fieldTypes) {
+			resp.Diagnostics.AddError("Query result error",
+				fmt.Sprintf("Type %q has %d field names but %d field types", name, len(fieldNames), len(fieldTypes)))
+			return
+		}
+
+		typeData := typesDataSourceTypeData{
+			Name:   types.String{Value: name},
+			Fields: make([]typesDataSourceFieldData, len(fieldNames)),
+		}
+		for j := range fieldNames {
+			typeData.Fields[j] = typesDataSourceFieldData{
+				Name: types.String{Value: fieldNames[j]},
+				Type: types.String{Value: fieldTypes[j]},
+			}
+		}
+		data.Types = append(data.Types, typeData)
+	}
+
+	data.Id = data.Keyspace
+
+	diags = resp.State.Set(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+}
@@ -0,0 +1,107 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/kiwicom/terraform-provider-scylla/internal/qb"
+)
+
+const (
+	// maxKeyspaceNameLength is the longest name of a keyspace the server accepts.
+	maxKeyspaceNameLength = 48
+
+	// maxTableNameLength is the longest name of a table the server accepts.
+	// The name is part of the name of the table directory, together with its ID.
+	maxTableNameLength = 222
+)
+
+// schemaName matches names of keyspaces and tables, which are restricted even when quoted.
+var schemaName = regexp.MustCompile(`^\w+$`)
+
+// identifierValidator checks that a string attribute is a CQL identifier of a keyspace or table.
+// Unquoted identifiers must not be reserved keywords, and the name the identifier refers to may only contain
+// letters, digits and underscores and must not exceed maxLength characters.
+type identifierValidator struct {
+	// kind is keyspace or table.
+	kind      string
+	maxLength int
+}
+
+func keyspaceNameValidator() tfsdk.AttributeValidator {
+	return identifierValidator{kind: "keyspace", maxLength: maxKeyspaceNameLength}
+}
+
+func tableNameValidator() tfsdk.AttributeValidator {
+	return identifierValidator{kind: "table", maxLength: maxTableNameLength}
+}
+
+func (v identifierValidator) Description(ctx context.Context) string {
+	return fmt.Sprintf("value must be a CQL identifier of a %s name of at most %d letters, digits and underscores",
+		v.kind, v.maxLength)
+}
+
+func (v identifierValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v identifierValidator) Validate(ctx context.Context, req tfsdk.ValidateAttributeRequest, resp *tfsdk.ValidateAttributeResponse) {
+	s, ok := req.AttributeConfig.(types.String)
+	if !ok || s.IsNull() || s.IsUnknown() {
+		return
+	}
+
+	switch {
+	case s.Value == "":
+		resp.Diagnostics.AddAttributeError(req.AttributePath, "Invalid name",
+			fmt.Sprintf("Name of the %s must not be empty.", v.kind))
+	case qb.ReservedKeyword(s.Value):
+		resp.Diagnostics.AddAttributeError(req.AttributePath, "Invalid name",
+			fmt.Sprintf("%q is a reserved keyword, enclose it in double quotes to use it as a name of a %s.", s.Value, v.kind))
+	case !qb.ValidIdentifier(s.Value) || !schemaName.MatchString(qb.NormalizeName(s.Value)):
+		resp.Diagnostics.AddAttributeError(req.AttributePath, "Invalid name",
+			fmt.Sprintf("Name of the %s %q may only contain letters, digits and underscores, "+
+				"and must start with a letter unless it is enclosed in double quotes.", v.kind, s.Value))
+	case len(qb.NormalizeName(s.Value)) > v.maxLength:
+		resp.Diagnostics.AddAttributeError(req.AttributePath, "Invalid name",
+			fmt.Sprintf("Name of the %s %q is longer than %d characters.", v.kind, s.Value, v.maxLength))
+	}
+}
+
+// nameValidator checks that a string attribute holding the name of a role or service level is not empty.
+// Such names are always quoted, so they may contain any characters.
+type nameValidator struct {
+	// kind is role or service level.
+	kind string
+}
+
+func roleNameValidator() tfsdk.AttributeValidator {
+	return nameValidator{kind: "role"}
+}
+
+func serviceLevelNameValidator() tfsdk.AttributeValidator {
+	return nameValidator{kind: "service level"}
+}
+
+func (v nameValidator) Description(ctx context.Context) string {
+	return fmt.Sprintf("value must be a non-empty %s name", v.kind)
+}
+
+func (v nameValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v nameValidator) Validate(ctx context.Context, req tfsdk.ValidateAttributeRequest, resp *tfsdk.ValidateAttributeResponse) {
+	s, ok := req.AttributeConfig.(types.String)
+	if !ok || s.IsNull() || s.IsUnknown() {
+		return
+	}
+	if s.Value == "" {
+		resp.Diagnostics.AddAttributeError(req.AttributePath, "Invalid name",
+			fmt.Sprintf("Name of the %s must not be empty.", v.kind))
+	}
+}
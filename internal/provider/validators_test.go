@@ -0,0 +1,60 @@
+package provider
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func validateString(v tfsdk.AttributeValidator, value types.String) *tfsdk.ValidateAttributeResponse {
+	resp := &tfsdk.ValidateAttributeResponse{}
+	v.Validate(context.Background(), tfsdk.ValidateAttributeRequest{
+		AttributePath:   path.Root("name"),
+		AttributeConfig: value,
+	}, resp)
+	return resp
+}
+
+func TestIdentifierValidator(t *testing.T) {
+	valid := []string{"ks", "MyKeyspace", "ks_1", `"MyKeyspace"`, `"select"`, `"1st"`, strings.Repeat("k", 48)}
+	for _, s := range valid {
+		resp := validateString(keyspaceNameValidator(), types.String{Value: s})
+		assert.False(t, resp.Diagnostics.HasError(), "%s: %v", s, resp.Diagnostics)
+	}
+
+	invalid := map[string]string{
+		"":                                  "must not be empty",
+		"select":                            "reserved keyword",
+		"my-ks":                             "may only contain",
+		`"with space"`:                      "may only contain",
+		"1st":                               "may only contain",
+		strings.Repeat("k", 49):             "longer than 48",
+		`"` + strings.Repeat("K", 49) + `"`: "longer than 48",
+	}
+	for s, detail := range invalid {
+		resp := validateString(keyspaceNameValidator(), types.String{Value: s})
+		if assert.True(t, resp.Diagnostics.HasError(), s) {
+			assert.Contains(t, resp.Diagnostics[0].Detail(), detail, s)
+		}
+	}
+
+	resp := validateString(tableNameValidator(), types.String{Value: strings.Repeat("t", 222)})
+	assert.False(t, resp.Diagnostics.HasError(), "%v", resp.Diagnostics)
+	resp = validateString(tableNameValidator(), types.String{Value: strings.Repeat("t", 223)})
+	assert.True(t, resp.Diagnostics.HasError())
+
+	assert.False(t, validateString(keyspaceNameValidator(), types.String{Null: true}).Diagnostics.HasError())
+	assert.False(t, validateString(keyspaceNameValidator(), types.String{Unknown: true}).Diagnostics.HasError())
+}
+
+func TestNameValidator(t *testing.T) {
+	assert.False(t, validateString(roleNameValidator(), types.String{Value: "with space-and/slash"}).Diagnostics.HasError())
+	assert.True(t, validateString(roleNameValidator(), types.String{Value: ""}).Diagnostics.HasError())
+	assert.True(t, validateString(serviceLevelNameValidator(), types.String{Value: ""}).Diagnostics.HasError())
+	assert.False(t, validateString(serviceLevelNameValidator(), types.String{Unknown: true}).Diagnostics.HasError())
+}
@@ -0,0 +1,40 @@
+package qb
+
+import "strconv"
+
+// EncryptionOptionsName is the table option holding the encryption at rest settings of Scylla Enterprise.
+const EncryptionOptionsName = "scylla_encryption_options"
+
+// EncryptionOptions are the encryption at rest settings of a table in Scylla Enterprise.
+// The server stores all of them as text, so they are rendered as text to compare equal to DESCRIBE output.
+type EncryptionOptions struct {
+	// KeyProvider is the factory of the key, for example LocalFileSystemKeyProviderFactory,
+	// ReplicatedKeyProviderFactory or KmsKeyProviderFactory, or none to disable encryption.
+	KeyProvider string
+
+	// CipherAlgorithm is for example AES/CBC/PKCS5Padding, empty leaves it to the server.
+	CipherAlgorithm string
+
+	// SecretKeyStrength is the length of the key in bits, zero leaves it to the server.
+	SecretKeyStrength int
+
+	// Options are the options of the key provider identifying the key,
+	// for example secret_key_file, system_key_file or master_key.
+	Options map[string]string
+}
+
+// CQL returns the value of the scylla_encryption_options table option.
+func (e EncryptionOptions) CQL() CQL {
+	m := make(map[string]string, len(e.Options)+3)
+	for k, v := range e.Options {
+		m[k] = v
+	}
+	m["key_provider"] = e.KeyProvider
+	if e.CipherAlgorithm != "" {
+		m["cipher_algorithm"] = e.CipherAlgorithm
+	}
+	if e.SecretKeyStrength != 0 {
+		m["secret_key_strength"] = strconv.Itoa(e.SecretKeyStrength)
+	}
+	return StringMap(m)
+}
@@ -0,0 +1,18 @@
+package qb
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEncryptionOptions_CQL(t *testing.T) {
+	assert.Equal(t, CQL(`{'cipher_algorithm': 'AES/CBC/PKCS5Padding', 'key_provider': 'LocalFileSystemKeyProviderFactory', `+
+		`'secret_key_file': '/etc/scylla/data_encryption_keys/key', 'secret_key_strength': '128'}`), EncryptionOptions{
+		KeyProvider:       "LocalFileSystemKeyProviderFactory",
+		CipherAlgorithm:   "AES/CBC/PKCS5Padding",
+		SecretKeyStrength: 128,
+		Options:           map[string]string{"secret_key_file": "/etc/scylla/data_encryption_keys/key"},
+	}.CQL())
+	assert.Equal(t, CQL(`{'key_provider': 'none'}`), EncryptionOptions{KeyProvider: "none"}.CQL())
+}
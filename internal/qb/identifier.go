@@ -0,0 +1,60 @@
+package qb
+
+import (
+	"regexp"
+	"strings"
+)
+
+var unquotedIdentifier = regexp.MustCompile(`^[a-zA-Z][a-zA-Z0-9_]*$`)
+
+// reservedKeywords cannot be used as unquoted identifiers.
+var reservedKeywords = map[string]struct{}{
+	"add": {}, "allow": {}, "alter": {}, "and": {}, "apply": {}, "asc": {}, "authorize": {}, "batch": {},
+	"begin": {}, "by": {}, "columnfamily": {}, "create": {}, "delete": {}, "desc": {}, "describe": {},
+	"drop": {}, "entries": {}, "execute": {}, "from": {}, "full": {}, "grant": {}, "if": {}, "in": {},
+	"index": {}, "infinity": {}, "insert": {}, "into": {}, "keyspace": {}, "limit": {}, "modify": {},
+	"nan": {}, "norecursive": {}, "not": {}, "null": {}, "of": {}, "on": {}, "or": {}, "order": {},
+	"primary": {}, "rename": {}, "replace": {}, "revoke": {}, "schema": {}, "select": {}, "set": {},
+	"table": {}, "to": {}, "token": {}, "truncate": {}, "unlogged": {}, "update": {}, "use": {},
+	"using": {}, "where": {}, "with": {},
+}
+
+// ValidIdentifier reports whether s is a valid CQL identifier, either unquoted, such as my_table,
+// or quoted, such as "MyTable" with inner double quotes doubled.
+func ValidIdentifier(s string) bool {
+	if isQuoted(s) {
+		inner := s[1 : len(s)-1]
+		return inner != "" && !strings.Contains(strings.ReplaceAll(inner, `""`, ""), `"`)
+	}
+	return unquotedIdentifier.MatchString(s) && !ReservedKeyword(s)
+}
+
+// ReservedKeyword reports whether s is a keyword that cannot be used as an unquoted identifier.
+func ReservedKeyword(s string) bool {
+	_, reserved := reservedKeywords[strings.ToLower(s)]
+	return reserved
+}
+
+// NormalizeName returns the name an identifier refers to, following the case-folding rules of CQL:
+// unquoted identifiers are case-insensitive and lowercased, quoted identifiers are case-sensitive
+// and only unquoted. Both MyTable and mytable refer to mytable, while "MyTable" refers to MyTable.
+func NormalizeName(s string) string {
+	if isQuoted(s) {
+		return strings.ReplaceAll(s[1:len(s)-1], `""`, `"`)
+	}
+	return strings.ToLower(s)
+}
+
+// Identifier returns the identifier that refers to name, the inverse of NormalizeName.
+// The name is left unquoted if it can be, otherwise it is quoted: mytable stays mytable,
+// while MyTable becomes "MyTable".
+func Identifier(name string) string {
+	if name == strings.ToLower(name) && unquotedIdentifier.MatchString(name) && !ReservedKeyword(name) {
+		return name
+	}
+	return string(QName(name))
+}
+
+func isQuoted(s string) bool {
+	return len(s) >= 2 && s[0] == '"' && s[len(s)-1] == '"'
+}
@@ -0,0 +1,39 @@
+package qb
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidIdentifier(t *testing.T) {
+	for _, s := range []string{"users", "MyTable", "t_1", `"MyTable"`, `"with space"`, `"a""b"`, `"select"`} {
+		assert.True(t, ValidIdentifier(s), s)
+	}
+	for _, s := range []string{"", "1users", "my-table", "with space", "select", "SELECT", `""`, `"a"b"`, `"unterminated`} {
+		assert.False(t, ValidIdentifier(s), s)
+	}
+}
+
+func TestReservedKeyword(t *testing.T) {
+	assert.True(t, ReservedKeyword("select"))
+	assert.True(t, ReservedKeyword("Keyspace"))
+	assert.False(t, ReservedKeyword("users"))
+}
+
+func TestNormalizeName(t *testing.T) {
+	assert.Equal(t, "mytable", NormalizeName("MyTable"))
+	assert.Equal(t, "mytable", NormalizeName("mytable"))
+	assert.Equal(t, "MyTable", NormalizeName(`"MyTable"`))
+	assert.Equal(t, `a"b`, NormalizeName(`"a""b"`))
+}
+
+func TestIdentifier(t *testing.T) {
+	assert.Equal(t, "mytable", Identifier("mytable"))
+	assert.Equal(t, `"MyTable"`, Identifier("MyTable"))
+	assert.Equal(t, `"select"`, Identifier("select"))
+	assert.Equal(t, `"a""b"`, Identifier(`a"b`))
+	for _, name := range []string{"mytable", "MyTable", "select", `a"b`, "with space"} {
+		assert.Equal(t, name, NormalizeName(Identifier(name)), name)
+	}
+}
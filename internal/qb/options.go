@@ -0,0 +1,75 @@
+package qb
+
+import (
+	"sort"
+	"strings"
+)
+
+// OptionsBuilder builds the WITH clause of a statement, for example WITH LOGIN = true AND SUPERUSER = false.
+// Options are rendered in the order they were first set. The zero value is ready to use.
+type OptionsBuilder struct {
+	names  []string
+	values map[string]CQL
+}
+
+// Set sets the option to the value. Setting an option again replaces its value.
+func (o *OptionsBuilder) Set(name string, value CQL) {
+	if o.values == nil {
+		o.values = make(map[string]CQL)
+	}
+	if _, ok := o.values[name]; !ok {
+		o.names = append(o.names, name)
+	}
+	o.values[name] = value
+}
+
+// SetMap sets the option to a map literal with text keys and values, for example replication of a keyspace.
+func (o *OptionsBuilder) SetMap(name string, value map[string]string) {
+	o.Set(name, StringMap(value))
+}
+
+// SetAll sets all options of the map, sorted by name.
+func (o *OptionsBuilder) SetAll(options map[string]CQL) {
+	names := make([]string, 0, len(options))
+	for name := range options {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		o.Set(name, options[name])
+	}
+}
+
+// Get returns the value of the option and whether it is set.
+func (o *OptionsBuilder) Get(name string) (CQL, bool) {
+	v, ok := o.values[name]
+	return v, ok
+}
+
+// Names returns the names of the set options in the order they were first set.
+func (o *OptionsBuilder) Names() []string {
+	return append([]string(nil), o.names...)
+}
+
+// Len returns the number of set options.
+func (o *OptionsBuilder) Len() int {
+	return len(o.names)
+}
+
+// CQL returns the WITH clause preceded by a space, or an empty string if no option is set.
+func (o *OptionsBuilder) CQL() CQL {
+	var sb strings.Builder
+	o.writeTo(&sb, " WITH ")
+	return CQL(sb.String())
+}
+
+// writeTo writes the options, the first one preceded by sep and the others by AND.
+func (o *OptionsBuilder) writeTo(sb *strings.Builder, sep string) {
+	for _, name := range o.names {
+		sb.WriteString(sep)
+		sb.WriteString(name)
+		sb.WriteString(" = ")
+		sb.WriteString(string(o.values[name]))
+		sep = " AND "
+	}
+}
@@ -0,0 +1,29 @@
+package qb
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOptionsBuilder(t *testing.T) {
+	var o OptionsBuilder
+	assert.Equal(t, CQL(""), o.CQL())
+
+	o.Set("LOGIN", Bool(true))
+	o.Set("SUPERUSER", Bool(false))
+	o.Set("LOGIN", Bool(false))
+	assert.Equal(t, 2, o.Len())
+	assert.Equal(t, CQL(" WITH LOGIN = false AND SUPERUSER = false"), o.CQL())
+}
+
+func TestOptionsBuilder_Nested(t *testing.T) {
+	var o OptionsBuilder
+	o.SetMap("replication", map[string]string{"class": "NetworkTopologyStrategy", "dc1": "3"})
+	o.SetAll(map[string]CQL{
+		"durable_writes": Bool(true),
+		"comment":        String("test"),
+	})
+	assert.Equal(t, CQL(" WITH replication = {'class': 'NetworkTopologyStrategy', 'dc1': '3'}"+
+		" AND comment = 'test' AND durable_writes = true"), o.CQL())
+}
@@ -1,15 +1,20 @@
 package qb
 
 import (
+	"encoding/hex"
 	"fmt"
+	"math"
+	"sort"
 	"strconv"
 	"strings"
+	"time"
 )
 
 // Builder builds CQL statements.
 type Builder struct {
 	stmt    strings.Builder
 	onceMap map[string]struct{}
+	params  []any
 }
 
 // Appendf appends a snippet of CQL to the query.
@@ -42,6 +47,22 @@ func (b *Builder) Once(key string, text, alt CQL) {
 	b.Append(text)
 }
 
+// AppendParam appends a bind marker and collects the value bound to it.
+func (b *Builder) AppendParam(v any) {
+	b.stmt.WriteString("?")
+	b.params = append(b.params, v)
+}
+
+// Params returns the values of the bind markers in the order they were appended.
+func (b *Builder) Params() []any {
+	return b.params
+}
+
+// Build returns the statement and the values of its bind markers.
+func (b *Builder) Build() (string, []any) {
+	return b.stmt.String(), b.params
+}
+
 func (b *Builder) String() string {
 	return b.stmt.String()
 }
@@ -79,6 +100,159 @@ func Int(i int) CQL {
 	return CQL(strconv.Itoa(i))
 }
 
+// BigInt returns CQL bigint literal.
+func BigInt(i int64) CQL {
+	return CQL(strconv.FormatInt(i, 10))
+}
+
+// Float returns CQL float or double literal.
+func Float(f float64) CQL {
+	switch {
+	case math.IsNaN(f):
+		return "NaN"
+	case math.IsInf(f, 1):
+		return "Infinity"
+	case math.IsInf(f, -1):
+		return "-Infinity"
+	}
+	s := strconv.FormatFloat(f, 'g', -1, 64)
+	if !strings.ContainsAny(s, ".e") {
+		// Make sure it is not parsed as an integer.
+		s += ".0"
+	}
+	return CQL(s)
+}
+
+// UUID returns CQL uuid or timeuuid literal.
+func UUID(u [16]byte) CQL {
+	var sb strings.Builder
+	for i, b := range u {
+		if i == 4 || i == 6 || i == 8 || i == 10 {
+			sb.WriteByte('-')
+		}
+		sb.WriteString(hex.EncodeToString([]byte{b}))
+	}
+	return CQL(sb.String())
+}
+
+// Timestamp returns CQL timestamp literal with millisecond precision.
+func Timestamp(t time.Time) CQL {
+	return String(t.UTC().Format("2006-01-02T15:04:05.000-0700"))
+}
+
+// Blob returns CQL blob literal.
+func Blob(b []byte) CQL {
+	return CQL("0x" + hex.EncodeToString(b))
+}
+
+// Duration returns CQL duration literal, for example 1h30m.
+func Duration(d time.Duration) CQL {
+	if d == 0 {
+		return "0s"
+	}
+	var sb strings.Builder
+	if d < 0 {
+		sb.WriteByte('-')
+		d = -d
+	}
+	units := []struct {
+		unit time.Duration
+		name string
+	}{
+		{time.Hour, "h"},
+		{time.Minute, "m"},
+		{time.Second, "s"},
+		{time.Millisecond, "ms"},
+		{time.Microsecond, "us"},
+		{time.Nanosecond, "ns"},
+	}
+	for _, u := range units {
+		if n := d / u.unit; n > 0 {
+			sb.WriteString(strconv.FormatInt(int64(n), 10))
+			sb.WriteString(u.name)
+			d -= n * u.unit
+		}
+	}
+	return CQL(sb.String())
+}
+
+// List returns CQL list literal of the items.
+func List(items ...CQL) CQL {
+	return "[" + join(items) + "]"
+}
+
+// Set returns CQL set literal of the items.
+func Set(items ...CQL) CQL {
+	return "{" + join(items) + "}"
+}
+
+// Tuple returns CQL tuple literal of the items.
+func Tuple(items ...CQL) CQL {
+	return "(" + join(items) + ")"
+}
+
+// Map returns CQL map literal, entries are sorted by key so that the result is stable.
+func Map(m map[CQL]CQL) CQL {
+	keys := make([]CQL, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool { return keys[i] < keys[j] })
+	entries := make([]CQL, len(keys))
+	for i, k := range keys {
+		entries[i] = k + ": " + m[k]
+	}
+	return "{" + join(entries) + "}"
+}
+
+// StringMap returns CQL map literal with text keys and values,
+// for example {'class': 'NetworkTopologyStrategy', 'dc1': '3'}.
+func StringMap(m map[string]string) CQL {
+	cqlMap := make(map[CQL]CQL, len(m))
+	for k, v := range m {
+		cqlMap[String(k)] = String(v)
+	}
+	return Map(cqlMap)
+}
+
+// NetworkTopologyReplication returns the replication map of NetworkTopologyStrategy
+// with the replication factors of the datacenters, for example
+// {'class': 'NetworkTopologyStrategy', 'dc1': '3', 'dc2': '2'}.
+func NetworkTopologyReplication(replicationFactors map[string]int) CQL {
+	m := map[string]string{"class": "NetworkTopologyStrategy"}
+	for dc, rf := range replicationFactors {
+		m[dc] = strconv.Itoa(rf)
+	}
+	return StringMap(m)
+}
+
+// SimpleReplication returns the replication map of SimpleStrategy with the replication factor,
+// for example {'class': 'SimpleStrategy', 'replication_factor': '1'}.
+func SimpleReplication(replicationFactor int) CQL {
+	return StringMap(map[string]string{"class": "SimpleStrategy", "replication_factor": strconv.Itoa(replicationFactor)})
+}
+
+// Tablets returns the tablets option of a keyspace, for example {'enabled': true, 'initial': 8}.
+// Zero initial leaves the initial number of tablets to the server.
+func Tablets(enabled bool, initial int) CQL {
+	m := map[CQL]CQL{String("enabled"): Bool(enabled)}
+	if enabled && initial > 0 {
+		m[String("initial")] = Int(initial)
+	}
+	return Map(m)
+}
+
+func join(items []CQL) CQL {
+	var sb strings.Builder
+	for i, item := range items {
+		if i > 0 {
+			sb.WriteString(", ")
+		}
+		sb.WriteString(string(item))
+	}
+	return CQL(sb.String())
+}
+
 func ToUpper(c CQL) CQL {
 	return CQL(strings.ToUpper(string(c)))
 }
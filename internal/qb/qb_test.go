@@ -1,9 +1,12 @@
 package qb
 
 import (
+	"math"
+	"testing"
+	"time"
+
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
-	"testing"
 )
 
 func TestBuilder_Appendf(t *testing.T) {
@@ -12,6 +15,17 @@ func TestBuilder_Appendf(t *testing.T) {
 	require.Equal(t, "TEXT WITH some CQL PLACEHOLDER", b.String())
 }
 
+func TestBuilder_AppendParam(t *testing.T) {
+	var b Builder
+	b.Append("SELECT column_name FROM system_schema.columns WHERE keyspace_name = ")
+	b.AppendParam("ks")
+	b.Append(" AND table_name = ")
+	b.AppendParam("tbl")
+	stmt, params := b.Build()
+	assert.Equal(t, "SELECT column_name FROM system_schema.columns WHERE keyspace_name = ? AND table_name = ?", stmt)
+	assert.Equal(t, []any{"ks", "tbl"}, params)
+}
+
 func TestString(t *testing.T) {
 	assert.Equal(t, CQL(`'Joe''s string'`), String("Joe's string"))
 }
@@ -19,3 +33,70 @@ func TestString(t *testing.T) {
 func TestQName(t *testing.T) {
 	assert.Equal(t, CQL(`"the_""cool""_identifier"`), QName(`the_"cool"_identifier`))
 }
+
+func TestBigInt(t *testing.T) {
+	assert.Equal(t, CQL("-9223372036854775808"), BigInt(math.MinInt64))
+}
+
+func TestFloat(t *testing.T) {
+	assert.Equal(t, CQL("3.0"), Float(3))
+	assert.Equal(t, CQL("-0.25"), Float(-0.25))
+	assert.Equal(t, CQL("1e+21"), Float(1e21))
+	assert.Equal(t, CQL("NaN"), Float(math.NaN()))
+	assert.Equal(t, CQL("Infinity"), Float(math.Inf(1)))
+	assert.Equal(t, CQL("-Infinity"), Float(math.Inf(-1)))
+}
+
+func TestUUID(t *testing.T) {
+	u := [16]byte{0x12, 0x3e, 0x45, 0x67, 0xe8, 0x9b, 0x12, 0xd3, 0xa4, 0x56, 0x42, 0x66, 0x14, 0x17, 0x40, 0x00}
+	assert.Equal(t, CQL("123e4567-e89b-12d3-a456-426614174000"), UUID(u))
+}
+
+func TestTimestamp(t *testing.T) {
+	ts := time.Date(2022, 10, 9, 12, 30, 15, 123456789, time.FixedZone("CEST", 2*60*60))
+	assert.Equal(t, CQL("'2022-10-09T10:30:15.123+0000'"), Timestamp(ts))
+}
+
+func TestBlob(t *testing.T) {
+	assert.Equal(t, CQL("0xcafe00"), Blob([]byte{0xca, 0xfe, 0x00}))
+	assert.Equal(t, CQL("0x"), Blob(nil))
+}
+
+func TestDuration(t *testing.T) {
+	assert.Equal(t, CQL("0s"), Duration(0))
+	assert.Equal(t, CQL("1h30m"), Duration(90*time.Minute))
+	assert.Equal(t, CQL("2s500ms"), Duration(2500*time.Millisecond))
+	assert.Equal(t, CQL("-1m1us"), Duration(-time.Minute-time.Microsecond))
+	assert.Equal(t, CQL("49h"), Duration(49*time.Hour))
+}
+
+func TestCollections(t *testing.T) {
+	assert.Equal(t, CQL("[1, 2]"), List(Int(1), Int(2)))
+	assert.Equal(t, CQL("{'a', 'b'}"), Set(String("a"), String("b")))
+	assert.Equal(t, CQL("{}"), Set())
+	assert.Equal(t, CQL("(1, 'it''s', true)"), Tuple(Int(1), String("it's"), Bool(true)))
+	assert.Equal(t, CQL("{1: [true], 2: []}"), Map(map[CQL]CQL{Int(2): List(), Int(1): List(Bool(true))}))
+}
+
+func TestStringMap(t *testing.T) {
+	assert.Equal(t, CQL("{'class': 'NetworkTopologyStrategy', 'dc''1': '3'}"), StringMap(map[string]string{
+		"dc'1":  "3",
+		"class": "NetworkTopologyStrategy",
+	}))
+}
+
+func TestNetworkTopologyReplication(t *testing.T) {
+	assert.Equal(t, CQL("{'class': 'NetworkTopologyStrategy', 'eu-west': '3', 'us-east': '2'}"),
+		NetworkTopologyReplication(map[string]int{"us-east": 2, "eu-west": 3}))
+	assert.Equal(t, CQL("{'class': 'NetworkTopologyStrategy'}"), NetworkTopologyReplication(nil))
+}
+
+func TestTablets(t *testing.T) {
+	assert.Equal(t, CQL("{'enabled': true, 'initial': 8}"), Tablets(true, 8))
+	assert.Equal(t, CQL("{'enabled': true}"), Tablets(true, 0))
+	assert.Equal(t, CQL("{'enabled': false}"), Tablets(false, 8))
+}
+
+func TestSimpleReplication(t *testing.T) {
+	assert.Equal(t, CQL("{'class': 'SimpleStrategy', 'replication_factor': '1'}"), SimpleReplication(1))
+}
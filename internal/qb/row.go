@@ -0,0 +1,134 @@
+package qb
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Row is a row of a table written as a whole, for example a row of configuration managed by Terraform.
+type Row struct {
+	Keyspace string
+	Table    string
+
+	// Key holds the values of the primary key columns by column name.
+	Key map[string]CQL
+
+	// Values holds the values of the other columns by column name.
+	Values map[string]CQL
+}
+
+// Insert returns the INSERT statement of the row. With ifNotExists, the statement is a lightweight transaction
+// that is not applied if the row exists already, so that rows written by others are not overwritten.
+func (r *Row) Insert(ifNotExists bool) (CQL, error) {
+	if err := r.validate(); err != nil {
+		return "", err
+	}
+
+	names := append(sortedNames(r.Key), sortedNames(r.Values)...)
+	columns := make([]CQL, len(names))
+	values := make([]CQL, len(names))
+	for i, name := range names {
+		columns[i] = QName(name)
+		values[i] = r.value(name)
+	}
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "INSERT INTO %s.%s (%s) VALUES (%s)", QName(r.Keyspace), QName(r.Table), join(columns), join(values))
+	if ifNotExists {
+		sb.WriteString(" IF NOT EXISTS")
+	}
+	return CQL(sb.String()), nil
+}
+
+// Update returns the UPDATE statement setting the values of the row. With conditions, the statement is
+// a lightweight transaction applied only if the current values of the columns equal the conditions,
+// for example the values last written by Terraform, so that concurrent changes are not overwritten.
+func (r *Row) Update(conditions map[string]CQL) (CQL, error) {
+	if err := r.validate(); err != nil {
+		return "", err
+	}
+	if len(r.Values) == 0 {
+		return "", errors.New("row has no columns to update")
+	}
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "UPDATE %s.%s SET ", QName(r.Keyspace), QName(r.Table))
+	for i, name := range sortedNames(r.Values) {
+		if i > 0 {
+			sb.WriteString(", ")
+		}
+		fmt.Fprintf(&sb, "%s = %s", QName(name), r.Values[name])
+	}
+	r.writeWhere(&sb)
+	writeConditions(&sb, conditions)
+	return CQL(sb.String()), nil
+}
+
+// Delete returns the DELETE statement of the row. With conditions, the statement is a lightweight transaction
+// applied only if the current values of the columns equal the conditions.
+func (r *Row) Delete(conditions map[string]CQL) (CQL, error) {
+	if err := r.validate(); err != nil {
+		return "", err
+	}
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "DELETE FROM %s.%s", QName(r.Keyspace), QName(r.Table))
+	r.writeWhere(&sb)
+	writeConditions(&sb, conditions)
+	return CQL(sb.String()), nil
+}
+
+func (r *Row) validate() error {
+	if r.Keyspace == "" || r.Table == "" {
+		return errors.New("keyspace and table of the row must be set")
+	}
+	if len(r.Key) == 0 {
+		return errors.New("row has no primary key columns")
+	}
+	for name := range r.Values {
+		if _, ok := r.Key[name]; ok {
+			return fmt.Errorf("column %q is both a primary key column and a value", name)
+		}
+	}
+	return nil
+}
+
+func (r *Row) value(name string) CQL {
+	if v, ok := r.Key[name]; ok {
+		return v
+	}
+	return r.Values[name]
+}
+
+func (r *Row) writeWhere(sb *strings.Builder) {
+	for i, name := range sortedNames(r.Key) {
+		if i == 0 {
+			sb.WriteString(" WHERE ")
+		} else {
+			sb.WriteString(" AND ")
+		}
+		fmt.Fprintf(sb, "%s = %s", QName(name), r.Key[name])
+	}
+}
+
+func writeConditions(sb *strings.Builder, conditions map[string]CQL) {
+	for i, name := range sortedNames(conditions) {
+		if i == 0 {
+			sb.WriteString(" IF ")
+		} else {
+			sb.WriteString(" AND ")
+		}
+		fmt.Fprintf(sb, "%s = %s", QName(name), conditions[name])
+	}
+}
+
+func sortedNames(m map[string]CQL) []string {
+	names := make([]string, 0, len(m))
+	for name := range m {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,51 @@
+package qb
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRow(t *testing.T) {
+	row := Row{
+		Keyspace: "ks",
+		Table:    "settings",
+		Key:      map[string]CQL{"name": String("limit"), "env": String("prod")},
+		Values:   map[string]CQL{"value": String("10"), "owner": String("team")},
+	}
+
+	stmt, err := row.Insert(true)
+	require.NoError(t, err)
+	assert.Equal(t, CQL(`INSERT INTO "ks"."settings" ("env", "name", "owner", "value") `+
+		`VALUES ('prod', 'limit', 'team', '10') IF NOT EXISTS`), stmt)
+
+	stmt, err = row.Insert(false)
+	require.NoError(t, err)
+	assert.Equal(t, CQL(`INSERT INTO "ks"."settings" ("env", "name", "owner", "value") VALUES ('prod', 'limit', 'team', '10')`), stmt)
+
+	stmt, err = row.Update(map[string]CQL{"value": String("5")})
+	require.NoError(t, err)
+	assert.Equal(t, CQL(`UPDATE "ks"."settings" SET "owner" = 'team', "value" = '10' `+
+		`WHERE "env" = 'prod' AND "name" = 'limit' IF "value" = '5'`), stmt)
+
+	stmt, err = row.Update(nil)
+	require.NoError(t, err)
+	assert.Equal(t, CQL(`UPDATE "ks"."settings" SET "owner" = 'team', "value" = '10' WHERE "env" = 'prod' AND "name" = 'limit'`), stmt)
+
+	stmt, err = row.Delete(map[string]CQL{"owner": String("team"), "value": String("10")})
+	require.NoError(t, err)
+	assert.Equal(t, CQL(`DELETE FROM "ks"."settings" WHERE "env" = 'prod' AND "name" = 'limit' `+
+		`IF "owner" = 'team' AND "value" = '10'`), stmt)
+}
+
+func TestRowInvalid(t *testing.T) {
+	_, err := (&Row{Keyspace: "ks", Table: "t", Values: map[string]CQL{"v": Int(1)}}).Insert(false)
+	assert.EqualError(t, err, "row has no primary key columns")
+
+	_, err = (&Row{Keyspace: "ks", Table: "t", Key: map[string]CQL{"k": Int(1)}}).Update(nil)
+	assert.EqualError(t, err, "row has no columns to update")
+
+	_, err = (&Row{Keyspace: "ks", Table: "t", Key: map[string]CQL{"k": Int(1)}, Values: map[string]CQL{"k": Int(2)}}).Delete(nil)
+	assert.EqualError(t, err, `column "k" is both a primary key column and a value`)
+}
@@ -0,0 +1,188 @@
+package qb
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Column is a column of a table.
+type Column struct {
+	Name string
+	// Type is the CQL type of the column, for example text or map<text, int>.
+	Type string
+	// Static columns are shared by all rows of a partition.
+	Static bool
+}
+
+// typeAliases maps names of types to the names the server describes them with.
+var typeAliases = map[string]string{"varchar": "text"}
+
+// NormalizeType renders the CQL type canonically, so that equal types compare equal regardless of case,
+// spacing, quoting and aliases. For example MAP<varchar,INT> becomes map<text, int>.
+func NormalizeType(s string) string {
+	var sb strings.Builder
+	for i := 0; i < len(s); {
+		switch c := s[i]; {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+			i++
+		case c == ',':
+			sb.WriteString(", ")
+			i++
+		case c == '"':
+			// Quoted name of a user defined type, "" escapes a quote.
+			j := i + 1
+			for j < len(s) {
+				if s[j] == '"' {
+					if j+1 < len(s) && s[j+1] == '"' {
+						j += 2
+						continue
+					}
+					j++
+					break
+				}
+				j++
+			}
+			name := NormalizeName(s[i:j])
+			if ValidIdentifier(name) && name == strings.ToLower(name) {
+				sb.WriteString(name)
+			} else {
+				sb.WriteString(string(QName(name)))
+			}
+			i = j
+		case c == '<' || c == '>' || c == '.':
+			sb.WriteByte(c)
+			i++
+		default:
+			j := i
+			for j < len(s) && !strings.ContainsRune(" \t\n\r,<>.\"", rune(s[j])) {
+				j++
+			}
+			name := strings.ToLower(s[i:j])
+			if alias, ok := typeAliases[name]; ok {
+				name = alias
+			}
+			sb.WriteString(name)
+			i = j
+		}
+	}
+	return sb.String()
+}
+
+// ClusteringColumn is a column of the clustering key of a table.
+type ClusteringColumn struct {
+	Name       string
+	Descending bool
+}
+
+// Table describes a table to be created by CREATE TABLE.
+type Table struct {
+	Keyspace      string
+	Name          string
+	Columns       []Column
+	PartitionKey  []string
+	ClusteringKey []ClusteringColumn
+	Options       OptionsBuilder
+	IfNotExists   bool
+}
+
+// CreateTable returns the CREATE TABLE statement of the table in canonical form:
+// the partition key is always parenthesized and the clustering order of every clustering column is stated.
+func (t *Table) CreateTable() (CQL, error) {
+	if err := t.validate(); err != nil {
+		return "", err
+	}
+
+	var sb strings.Builder
+	sb.WriteString("CREATE TABLE ")
+	if t.IfNotExists {
+		sb.WriteString("IF NOT EXISTS ")
+	}
+	fmt.Fprintf(&sb, "%s.%s (", QName(t.Keyspace), QName(t.Name))
+	for _, c := range t.Columns {
+		fmt.Fprintf(&sb, "%s %s", QName(c.Name), c.Type)
+		if c.Static {
+			sb.WriteString(" STATIC")
+		}
+		sb.WriteString(", ")
+	}
+
+	partitionKey := make([]CQL, len(t.PartitionKey))
+	for i, name := range t.PartitionKey {
+		partitionKey[i] = QName(name)
+	}
+	fmt.Fprintf(&sb, "PRIMARY KEY ((%s)", join(partitionKey))
+	for _, c := range t.ClusteringKey {
+		fmt.Fprintf(&sb, ", %s", QName(c.Name))
+	}
+	sb.WriteString("))")
+
+	sep := " WITH "
+	if len(t.ClusteringKey) > 0 {
+		order := make([]CQL, len(t.ClusteringKey))
+		for i, c := range t.ClusteringKey {
+			order[i] = QName(c.Name) + " ASC"
+			if c.Descending {
+				order[i] = QName(c.Name) + " DESC"
+			}
+		}
+		fmt.Fprintf(&sb, " WITH CLUSTERING ORDER BY (%s)", join(order))
+		sep = " AND "
+	}
+	t.Options.writeTo(&sb, sep)
+	return CQL(sb.String()), nil
+}
+
+func (t *Table) validate() error {
+	if t.Keyspace == "" || t.Name == "" {
+		return fmt.Errorf("keyspace and name of the table must be set")
+	}
+	if len(t.PartitionKey) == 0 {
+		return fmt.Errorf("table %s.%s: partition key must have at least one column", t.Keyspace, t.Name)
+	}
+
+	columns := make(map[string]Column, len(t.Columns))
+	for _, c := range t.Columns {
+		if _, ok := columns[c.Name]; ok {
+			return fmt.Errorf("table %s.%s: column %q is defined more than once", t.Keyspace, t.Name, c.Name)
+		}
+		if c.Type == "" {
+			return fmt.Errorf("table %s.%s: column %q has no type", t.Keyspace, t.Name, c.Name)
+		}
+		columns[c.Name] = c
+	}
+
+	keys := make(map[string]struct{})
+	checkKey := func(name string) error {
+		c, ok := columns[name]
+		if !ok {
+			return fmt.Errorf("table %s.%s: key column %q is not defined", t.Keyspace, t.Name, name)
+		}
+		if c.Static {
+			return fmt.Errorf("table %s.%s: key column %q cannot be static", t.Keyspace, t.Name, name)
+		}
+		if _, ok := keys[name]; ok {
+			return fmt.Errorf("table %s.%s: column %q is in the primary key more than once", t.Keyspace, t.Name, name)
+		}
+		keys[name] = struct{}{}
+		return nil
+	}
+	for _, name := range t.PartitionKey {
+		if err := checkKey(name); err != nil {
+			return err
+		}
+	}
+	for _, c := range t.ClusteringKey {
+		if err := checkKey(c.Name); err != nil {
+			return err
+		}
+	}
+
+	if len(t.ClusteringKey) == 0 {
+		for _, c := range t.Columns {
+			if c.Static {
+				return fmt.Errorf("table %s.%s: static column %q requires a clustering key", t.Keyspace, t.Name, c.Name)
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,40 @@
+package qb
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Caching returns the caching option of a table. keys is ALL or NONE,
+// rowsPerPartition is ALL, NONE or a number of rows.
+func Caching(keys, rowsPerPartition string) CQL {
+	return StringMap(map[string]string{
+		"keys":               strings.ToUpper(keys),
+		"rows_per_partition": strings.ToUpper(rowsPerPartition),
+	})
+}
+
+// SpeculativeRetry returns the speculative_retry option of a table in the form the server describes it,
+// for example '99.0PERCENTILE' for 99percentile and '50.00ms' for 50ms, so that it compares equal to DESCRIBE output.
+func SpeculativeRetry(s string) (CQL, error) {
+	upper := strings.ToUpper(strings.TrimSpace(s))
+	switch {
+	case upper == "NONE" || upper == "ALWAYS":
+		return String(upper), nil
+	case strings.HasSuffix(upper, "PERCENTILE"):
+		v, err := strconv.ParseFloat(strings.TrimSuffix(upper, "PERCENTILE"), 64)
+		if err != nil || v < 0 || v > 100 {
+			return "", fmt.Errorf("invalid speculative_retry %q: percentile must be between 0 and 100", s)
+		}
+		return String(strconv.FormatFloat(v, 'f', 1, 64) + "PERCENTILE"), nil
+	case strings.HasSuffix(upper, "MS"):
+		v, err := strconv.ParseFloat(strings.TrimSuffix(upper, "MS"), 64)
+		if err != nil || v < 0 {
+			return "", fmt.Errorf("invalid speculative_retry %q: delay must be a non-negative number of milliseconds", s)
+		}
+		return String(strconv.FormatFloat(v, 'f', 2, 64) + "ms"), nil
+	default:
+		return "", fmt.Errorf("invalid speculative_retry %q: must be NONE, ALWAYS, <n>PERCENTILE or <n>ms", s)
+	}
+}
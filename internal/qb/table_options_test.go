@@ -0,0 +1,31 @@
+package qb
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCaching(t *testing.T) {
+	assert.Equal(t, CQL("{'keys': 'ALL', 'rows_per_partition': 'NONE'}"), Caching("all", "none"))
+	assert.Equal(t, CQL("{'keys': 'ALL', 'rows_per_partition': '100'}"), Caching("ALL", "100"))
+}
+
+func TestSpeculativeRetry(t *testing.T) {
+	for in, want := range map[string]CQL{
+		"99percentile":   "'99.0PERCENTILE'",
+		"99.0PERCENTILE": "'99.0PERCENTILE'",
+		"50ms":           "'50.00ms'",
+		"12.5ms":         "'12.50ms'",
+		"always":         "'ALWAYS'",
+		" NONE ":         "'NONE'",
+	} {
+		got, err := SpeculativeRetry(in)
+		assert.NoError(t, err, in)
+		assert.Equal(t, want, got, in)
+	}
+	for _, in := range []string{"", "99p", "101PERCENTILE", "-1ms", "fastms"} {
+		_, err := SpeculativeRetry(in)
+		assert.Error(t, err, in)
+	}
+}
@@ -0,0 +1,126 @@
+package qb
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTable_CreateTable(t *testing.T) {
+	tests := []struct {
+		name  string
+		table Table
+		want  CQL
+	}{
+		{
+			name: "partition key only",
+			table: Table{
+				Keyspace:     "ks",
+				Name:         "users",
+				Columns:      []Column{{Name: "id", Type: "uuid"}, {Name: "name", Type: "text"}},
+				PartitionKey: []string{"id"},
+			},
+			want: `CREATE TABLE "ks"."users" ("id" uuid, "name" text, PRIMARY KEY (("id")))`,
+		},
+		{
+			name: "composite keys",
+			table: Table{
+				Keyspace: "ks",
+				Name:     "Events",
+				Columns: []Column{
+					{Name: "tenant", Type: "text"},
+					{Name: "day", Type: "date"},
+					{Name: "ts", Type: "timestamp"},
+					{Name: "seq", Type: "int"},
+					{Name: "owner", Type: "text", Static: true},
+					{Name: "tags", Type: "map<text, text>"},
+				},
+				PartitionKey:  []string{"tenant", "day"},
+				ClusteringKey: []ClusteringColumn{{Name: "ts", Descending: true}, {Name: "seq"}},
+				IfNotExists:   true,
+			},
+			want: `CREATE TABLE IF NOT EXISTS "ks"."Events" ("tenant" text, "day" date, "ts" timestamp, "seq" int, ` +
+				`"owner" text STATIC, "tags" map<text, text>, PRIMARY KEY (("tenant", "day"), "ts", "seq")) ` +
+				`WITH CLUSTERING ORDER BY ("ts" DESC, "seq" ASC)`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.table.CreateTable()
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestTable_CreateTableOptions(t *testing.T) {
+	table := Table{
+		Keyspace:      "ks",
+		Name:          "t",
+		Columns:       []Column{{Name: "k", Type: "int"}, {Name: "c", Type: "int"}},
+		PartitionKey:  []string{"k"},
+		ClusteringKey: []ClusteringColumn{{Name: "c"}},
+	}
+	table.Options.Set("comment", String("test"))
+	table.Options.SetMap("compaction", map[string]string{"class": "LeveledCompactionStrategy"})
+
+	got, err := table.CreateTable()
+	require.NoError(t, err)
+	assert.Equal(t, CQL(`CREATE TABLE "ks"."t" ("k" int, "c" int, PRIMARY KEY (("k"), "c")) `+
+		`WITH CLUSTERING ORDER BY ("c" ASC) AND comment = 'test' AND compaction = {'class': 'LeveledCompactionStrategy'}`), got)
+
+	table.ClusteringKey = nil
+	table.PartitionKey = []string{"k", "c"}
+	got, err = table.CreateTable()
+	require.NoError(t, err)
+	assert.Equal(t, CQL(`CREATE TABLE "ks"."t" ("k" int, "c" int, PRIMARY KEY (("k", "c"))) `+
+		`WITH comment = 'test' AND compaction = {'class': 'LeveledCompactionStrategy'}`), got)
+}
+
+func TestTable_CreateTableInvalid(t *testing.T) {
+	valid := func() Table {
+		return Table{
+			Keyspace:      "ks",
+			Name:          "t",
+			Columns:       []Column{{Name: "k", Type: "int"}, {Name: "c", Type: "int"}, {Name: "s", Type: "int", Static: true}},
+			PartitionKey:  []string{"k"},
+			ClusteringKey: []ClusteringColumn{{Name: "c"}},
+		}
+	}
+	tests := map[string]func(*Table){
+		"no keyspace":             func(t *Table) { t.Keyspace = "" },
+		"no partition key":        func(t *Table) { t.PartitionKey = nil },
+		"undefined key column":    func(t *Table) { t.PartitionKey = []string{"x"} },
+		"duplicate column":        func(t *Table) { t.Columns = append(t.Columns, Column{Name: "k", Type: "text"}) },
+		"column without type":     func(t *Table) { t.Columns[1].Type = "" },
+		"static key column":       func(t *Table) { t.ClusteringKey = []ClusteringColumn{{Name: "s"}} },
+		"repeated key column":     func(t *Table) { t.ClusteringKey = []ClusteringColumn{{Name: "k"}} },
+		"static without clusters": func(t *Table) { t.ClusteringKey = nil },
+	}
+	for name, modify := range tests {
+		t.Run(name, func(t *testing.T) {
+			table := valid()
+			modify(&table)
+			_, err := table.CreateTable()
+			assert.Error(t, err)
+		})
+	}
+	table := valid()
+	_, err := table.CreateTable()
+	assert.NoError(t, err)
+}
+
+func TestNormalizeType(t *testing.T) {
+	for in, want := range map[string]string{
+		"int":                             "int",
+		"VARCHAR":                         "text",
+		"MAP<varchar,INT>":                "map<text, int>",
+		"map< text , frozen<list<int>> >": "map<text, frozen<list<int>>>",
+		`frozen<"MyType">`:                `frozen<"MyType">`,
+		`frozen<"mytype">`:                "frozen<mytype>",
+		`ks.Address`:                      "ks.address",
+	} {
+		assert.Equal(t, want, NormalizeType(in), in)
+	}
+}
@@ -0,0 +1,184 @@
+// Package scan maps rows of CQL query results into structs.
+package scan
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/scylladb/scylla-go-driver/frame"
+	"github.com/scylladb/scylla-go-driver/transport"
+)
+
+// Rows maps all rows of the result into dst, which must be a pointer to a slice of structs.
+//
+// Fields are mapped to columns by the cql tag, for example `cql:"role"`. Fields without the tag are ignored.
+// The result must contain every tagged column unless the tag has the optional flag, for example
+// `cql:"shares,optional"`, in which case the field is left untouched if the column is missing.
+//
+// Supported field types are string, bool, int32, int64, float32, float64, time.Duration, frame.UUID,
+// []string and map[string]string. Pointers to these types are nil if the value is null, otherwise
+// null values are mapped to zero values. Fields of type frame.CqlValue get the value as it is,
+// for columns whose type differs between server versions.
+func Rows(result transport.QueryResult, dst interface{}) error {
+	v := reflect.ValueOf(dst)
+	if v.Kind() != reflect.Pointer || v.Elem().Kind() != reflect.Slice || v.Elem().Type().Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("destination must be a pointer to a slice of structs, got %T", dst)
+	}
+	slice := v.Elem()
+	m, err := newMapping(result.ColSpec, slice.Type().Elem())
+	if err != nil {
+		return err
+	}
+	rows := reflect.MakeSlice(slice.Type(), len(result.Rows), len(result.Rows))
+	for i := range result.Rows {
+		if err := m.scan(i, result.Rows[i], rows.Index(i)); err != nil {
+			return err
+		}
+	}
+	slice.Set(rows)
+	return nil
+}
+
+// Row maps the row of the result at index i into dst, which must be a pointer to a struct.
+// Fields are mapped as in Rows.
+func Row(result transport.QueryResult, i int, dst interface{}) error {
+	v := reflect.ValueOf(dst)
+	if v.Kind() != reflect.Pointer || v.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("destination must be a pointer to a struct, got %T", dst)
+	}
+	if i < 0 || i >= len(result.Rows) {
+		return fmt.Errorf("row %d out of range, result has %d rows", i, len(result.Rows))
+	}
+	m, err := newMapping(result.ColSpec, v.Elem().Type())
+	if err != nil {
+		return err
+	}
+	return m.scan(i, result.Rows[i], v.Elem())
+}
+
+// field maps a column to a struct field.
+type field struct {
+	index  int
+	column int
+	name   string
+}
+
+// mapping maps columns of a result to fields of a struct type.
+type mapping []field
+
+func newMapping(colSpec []frame.ColumnSpec, t reflect.Type) (mapping, error) {
+	columns := make(map[string]int, len(colSpec))
+	for i := range colSpec {
+		columns[colSpec[i].Name] = i
+	}
+
+	var m mapping
+	for i := 0; i < t.NumField(); i++ {
+		tag, ok := t.Field(i).Tag.Lookup("cql")
+		if !ok {
+			continue
+		}
+		name, flags, _ := strings.Cut(tag, ",")
+		column, ok := columns[name]
+		if !ok {
+			if flags == "optional" {
+				continue
+			}
+			return nil, fmt.Errorf("column %q not found in result", name)
+		}
+		m = append(m, field{index: i, column: column, name: name})
+	}
+	return m, nil
+}
+
+func (m mapping) scan(i int, row frame.Row, dst reflect.Value) error {
+	for _, f := range m {
+		if err := setValue(dst.Field(f.index), row[f.column]); err != nil {
+			return fmt.Errorf("row %d, column %q: %w", i, f.name, err)
+		}
+	}
+	return nil
+}
+
+var (
+	durationType = reflect.TypeOf(time.Duration(0))
+	uuidType     = reflect.TypeOf(frame.UUID{})
+	cqlValueType = reflect.TypeOf(frame.CqlValue{})
+)
+
+func setValue(dst reflect.Value, v frame.CqlValue) error {
+	if dst.Type() == cqlValueType {
+		dst.Set(reflect.ValueOf(v))
+		return nil
+	}
+
+	if dst.Kind() == reflect.Pointer {
+		if v.Value == nil {
+			dst.Set(reflect.Zero(dst.Type()))
+			return nil
+		}
+		ptr := reflect.New(dst.Type().Elem())
+		if err := setValue(ptr.Elem(), v); err != nil {
+			return err
+		}
+		dst.Set(ptr)
+		return nil
+	}
+
+	if v.Value == nil {
+		dst.Set(reflect.Zero(dst.Type()))
+		return nil
+	}
+
+	var value interface{}
+	var err error
+	switch dst.Type() {
+	case durationType:
+		var d frame.Duration
+		d, err = v.AsDuration()
+		// Months and days are ignored, they have no fixed length.
+		value = time.Duration(d.Nanoseconds)
+	case uuidType:
+		var u [16]byte
+		u, err = v.AsUUID()
+		value = frame.UUID(u)
+	default:
+		switch dst.Kind() {
+		case reflect.String:
+			if v.Type.ID == frame.ASCIIID {
+				value, err = v.AsASCII()
+			} else {
+				value, err = v.AsText()
+			}
+		case reflect.Bool:
+			value, err = v.AsBoolean()
+		case reflect.Int32:
+			value, err = v.AsInt32()
+		case reflect.Int64:
+			value, err = v.AsInt64()
+		case reflect.Float32:
+			value, err = v.AsFloat32()
+		case reflect.Float64:
+			value, err = v.AsFloat64()
+		case reflect.Slice:
+			if dst.Type().Elem().Kind() != reflect.String {
+				return fmt.Errorf("unsupported field type %s", dst.Type())
+			}
+			value, err = v.AsStringSlice()
+		case reflect.Map:
+			if dst.Type().Key().Kind() != reflect.String || dst.Type().Elem().Kind() != reflect.String {
+				return fmt.Errorf("unsupported field type %s", dst.Type())
+			}
+			value, err = v.AsStringMap()
+		default:
+			return fmt.Errorf("unsupported field type %s", dst.Type())
+		}
+	}
+	if err != nil {
+		return err
+	}
+	dst.Set(reflect.ValueOf(value).Convert(dst.Type()))
+	return nil
+}
@@ -0,0 +1,84 @@
+package scan
+
+import (
+	"testing"
+
+	"github.com/scylladb/scylla-go-driver/frame"
+	"github.com/scylladb/scylla-go-driver/transport"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func text(t *testing.T, s string) frame.CqlValue {
+	v, err := frame.CqlFromText(s)
+	require.NoError(t, err)
+	return v
+}
+
+func result(t *testing.T) transport.QueryResult {
+	return transport.QueryResult{
+		ColSpec: []frame.ColumnSpec{{Name: "role"}, {Name: "login"}, {Name: "shares"}},
+		Rows: []frame.Row{
+			{text(t, "app"), frame.CqlFromBoolean(true), frame.CqlFromInt32(100)},
+			{text(t, "reader"), frame.CqlFromBoolean(false), frame.CqlValue{Type: &frame.Option{ID: frame.IntID}}},
+		},
+	}
+}
+
+func TestRows(t *testing.T) {
+	type role struct {
+		Name    string `cql:"role"`
+		Login   bool   `cql:"login"`
+		Shares  *int32 `cql:"shares"`
+		Missing string `cql:"missing,optional"`
+		Ignored string
+	}
+
+	var roles []role
+	require.NoError(t, Rows(result(t), &roles))
+	require.Len(t, roles, 2)
+	assert.Equal(t, "app", roles[0].Name)
+	assert.True(t, roles[0].Login)
+	require.NotNil(t, roles[0].Shares)
+	assert.Equal(t, int32(100), *roles[0].Shares)
+	assert.Equal(t, "reader", roles[1].Name)
+	assert.False(t, roles[1].Login)
+	assert.Nil(t, roles[1].Shares)
+}
+
+func TestRow(t *testing.T) {
+	var r struct {
+		Name string `cql:"role"`
+	}
+	require.NoError(t, Row(result(t), 1, &r))
+	assert.Equal(t, "reader", r.Name)
+
+	assert.Error(t, Row(result(t), 2, &r))
+}
+
+func TestRowCqlValue(t *testing.T) {
+	var r struct {
+		Shares frame.CqlValue `cql:"shares"`
+	}
+	require.NoError(t, Row(result(t), 0, &r))
+	assert.Equal(t, frame.CqlFromInt32(100), r.Shares)
+
+	require.NoError(t, Row(result(t), 1, &r))
+	assert.Nil(t, r.Shares.Value)
+}
+
+func TestErrors(t *testing.T) {
+	var missing []struct {
+		Name string `cql:"name"`
+	}
+	assert.EqualError(t, Rows(result(t), &missing), `column "name" not found in result`)
+
+	var wrongType []struct {
+		Login string `cql:"login"`
+	}
+	err := Rows(result(t), &wrongType)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `row 0, column "login"`)
+
+	assert.Error(t, Rows(result(t), []struct{}{}))
+}
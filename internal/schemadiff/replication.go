@@ -0,0 +1,49 @@
+package schemadiff
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// ReplicationWarnings returns problems of the replication of a keyspace, given as its map of options,
+// in a cluster with the datacenters. They do not make the statement fail, but make the keyspace
+// unsuitable for production:
+//   - SimpleStrategy places replicas regardless of datacenters, so a multi-datacenter cluster can
+//     lose all replicas of a partition with a single datacenter, and LOCAL_* consistency levels
+//     may need replies from remote ones.
+//   - NetworkTopologyStrategy with a datacenter that is not in the cluster has no replicas there.
+func ReplicationWarnings(replication map[string]string, datacenters []string) []string {
+	known := make(map[string]struct{}, len(datacenters))
+	for _, dc := range datacenters {
+		known[dc] = struct{}{}
+	}
+
+	class := replication["class"]
+	class = class[strings.LastIndex(class, ".")+1:]
+	var warnings []string
+	switch class {
+	case "SimpleStrategy":
+		if len(datacenters) > 1 {
+			warnings = append(warnings, fmt.Sprintf("SimpleStrategy ignores datacenters, but the cluster has %d of them (%s). "+
+				"Use NetworkTopologyStrategy with a replication factor for every datacenter.",
+				len(datacenters), strings.Join(datacenters, ", ")))
+		}
+	case "NetworkTopologyStrategy":
+		var unknown []string
+		for key := range replication {
+			if key == "class" || key == "replication_factor" {
+				continue
+			}
+			if _, ok := known[key]; !ok && len(datacenters) > 0 {
+				unknown = append(unknown, key)
+			}
+		}
+		sort.Strings(unknown)
+		for _, dc := range unknown {
+			warnings = append(warnings, fmt.Sprintf("Datacenter %q is not in the cluster, which has %s. "+
+				"The keyspace has no replicas in it.", dc, strings.Join(datacenters, ", ")))
+		}
+	}
+	return warnings
+}
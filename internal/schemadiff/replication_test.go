@@ -0,0 +1,23 @@
+package schemadiff
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReplicationWarnings(t *testing.T) {
+	simple := map[string]string{"class": "SimpleStrategy", "replication_factor": "1"}
+	assert.Empty(t, ReplicationWarnings(simple, []string{"dc1"}))
+	assert.Len(t, ReplicationWarnings(simple, []string{"dc1", "dc2"}), 1)
+	assert.Len(t, ReplicationWarnings(map[string]string{
+		"class": "org.apache.cassandra.locator.SimpleStrategy", "replication_factor": "3",
+	}, []string{"dc1", "dc2"}), 1)
+
+	nts := map[string]string{"class": "NetworkTopologyStrategy", "dc1": "3", "dc3": "3"}
+	assert.Empty(t, ReplicationWarnings(nts, nil))
+	warnings := ReplicationWarnings(nts, []string{"dc1", "dc2"})
+	if assert.Len(t, warnings, 1) {
+		assert.Contains(t, warnings[0], `"dc3"`)
+	}
+}
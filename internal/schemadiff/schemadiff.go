@@ -0,0 +1,169 @@
+// Package schemadiff computes ALTER statements that change keyspaces and tables
+// from their actual to their desired definition in place.
+package schemadiff
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/kiwicom/terraform-provider-scylla/internal/qb"
+)
+
+// ErrRequiresReplacement is returned when the change cannot be made by ALTER statements,
+// the object has to be dropped and created again.
+var ErrRequiresReplacement = errors.New("change requires replacement")
+
+// Keyspace describes a keyspace by its options, for example replication and durable_writes.
+type Keyspace struct {
+	Name    string
+	Options qb.OptionsBuilder
+}
+
+// AlterKeyspace returns the statements changing the actual keyspace to the desired one.
+// Options that are set in actual but not in desired are kept as they are.
+// Changes of tablets require replacement, a keyspace cannot be migrated between vnodes and tablets.
+func AlterKeyspace(actual, desired *Keyspace) ([]qb.CQL, error) {
+	if actual.Name != desired.Name {
+		return nil, fmt.Errorf("%w: keyspace renamed from %q to %q", ErrRequiresReplacement, actual.Name, desired.Name)
+	}
+	options := changedOptions(&actual.Options, &desired.Options)
+	if tablets, ok := options.Get("tablets"); ok {
+		was, ok := actual.Options.Get("tablets")
+		if !ok {
+			// Keyspaces using vnodes have no tablets option.
+			was = qb.Tablets(false, 0)
+		}
+		if tablets != was {
+			return nil, fmt.Errorf("%w: tablets changed from %s to %s", ErrRequiresReplacement, was, tablets)
+		}
+		options = changedOptions(&actual.Options, withoutOption(&desired.Options, "tablets"))
+	}
+	if options.Len() == 0 {
+		return nil, nil
+	}
+	return []qb.CQL{"ALTER KEYSPACE " + qb.QName(desired.Name) + options.CQL()}, nil
+}
+
+// AlterTable returns the statements changing the actual table to the desired one, in the order they must be executed:
+// dropping removed columns, adding new columns and changing options.
+// Options that are set in actual but not in desired are kept as they are.
+// Changes of the primary key, of the clustering order and of types of columns require replacement.
+// Types are compared normalized, so that spelling a type differently than the server does is not a change.
+func AlterTable(actual, desired *qb.Table) ([]qb.CQL, error) {
+	if actual.Keyspace != desired.Keyspace || actual.Name != desired.Name {
+		return nil, fmt.Errorf("%w: table renamed from %s.%s to %s.%s", ErrRequiresReplacement,
+			actual.Keyspace, actual.Name, desired.Keyspace, desired.Name)
+	}
+	if !equalStrings(actual.PartitionKey, desired.PartitionKey) {
+		return nil, fmt.Errorf("%w: partition key changed from (%s) to (%s)", ErrRequiresReplacement,
+			strings.Join(actual.PartitionKey, ", "), strings.Join(desired.PartitionKey, ", "))
+	}
+	if !equalClusteringKeys(actual.ClusteringKey, desired.ClusteringKey) {
+		return nil, fmt.Errorf("%w: clustering key or order changed", ErrRequiresReplacement)
+	}
+
+	actualColumns := make(map[string]qb.Column, len(actual.Columns))
+	for _, c := range actual.Columns {
+		actualColumns[c.Name] = c
+	}
+	desiredColumns := make(map[string]qb.Column, len(desired.Columns))
+	for _, c := range desired.Columns {
+		desiredColumns[c.Name] = c
+	}
+
+	var dropped, added []qb.CQL
+	for _, c := range actual.Columns {
+		if _, ok := desiredColumns[c.Name]; !ok {
+			dropped = append(dropped, qb.QName(c.Name))
+		}
+	}
+	for _, c := range desired.Columns {
+		a, ok := actualColumns[c.Name]
+		if !ok {
+			def := qb.QName(c.Name) + " " + qb.CQL(c.Type)
+			if c.Static {
+				def += " STATIC"
+			}
+			added = append(added, def)
+			continue
+		}
+		if qb.NormalizeType(a.Type) != qb.NormalizeType(c.Type) {
+			return nil, fmt.Errorf("%w: type of column %q changed from %s to %s", ErrRequiresReplacement,
+				c.Name, a.Type, c.Type)
+		}
+		if a.Static != c.Static {
+			return nil, fmt.Errorf("%w: column %q changed static", ErrRequiresReplacement, c.Name)
+		}
+	}
+
+	table := qb.QName(desired.Keyspace) + "." + qb.QName(desired.Name)
+	var stmts []qb.CQL
+	if len(dropped) > 0 {
+		stmts = append(stmts, "ALTER TABLE "+table+" DROP "+list(dropped))
+	}
+	if len(added) > 0 {
+		stmts = append(stmts, "ALTER TABLE "+table+" ADD "+list(added))
+	}
+	if options := changedOptions(&actual.Options, &desired.Options); options.Len() > 0 {
+		stmts = append(stmts, "ALTER TABLE "+table+options.CQL())
+	}
+	return stmts, nil
+}
+
+// changedOptions returns the options of desired whose value differs from actual.
+func changedOptions(actual, desired *qb.OptionsBuilder) *qb.OptionsBuilder {
+	var changed qb.OptionsBuilder
+	for _, name := range desired.Names() {
+		want, _ := desired.Get(name)
+		if got, ok := actual.Get(name); ok && got == want {
+			continue
+		}
+		changed.Set(name, want)
+	}
+	return &changed
+}
+
+// withoutOption returns a copy of the options without the named one.
+func withoutOption(o *qb.OptionsBuilder, name string) *qb.OptionsBuilder {
+	var result qb.OptionsBuilder
+	for _, n := range o.Names() {
+		if n != name {
+			v, _ := o.Get(n)
+			result.Set(n, v)
+		}
+	}
+	return &result
+}
+
+// list returns a single item as it is and more items parenthesized, as used by ADD and DROP.
+func list(items []qb.CQL) qb.CQL {
+	if len(items) == 1 {
+		return items[0]
+	}
+	return qb.Tuple(items...)
+}
+
+func equalStrings(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func equalClusteringKeys(a, b []qb.ClusteringColumn) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,112 @@
+package schemadiff
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kiwicom/terraform-provider-scylla/internal/qb"
+)
+
+func testTable() *qb.Table {
+	t := &qb.Table{
+		Keyspace:      "ks",
+		Name:          "t",
+		Columns:       []qb.Column{{Name: "k", Type: "int"}, {Name: "c", Type: "int"}, {Name: "v", Type: "text"}},
+		PartitionKey:  []string{"k"},
+		ClusteringKey: []qb.ClusteringColumn{{Name: "c", Descending: true}},
+	}
+	t.Options.Set("comment", qb.String("test"))
+	t.Options.Set("gc_grace_seconds", qb.Int(864000))
+	return t
+}
+
+func TestAlterTable(t *testing.T) {
+	actual, desired := testTable(), testTable()
+	stmts, err := AlterTable(actual, desired)
+	require.NoError(t, err)
+	assert.Empty(t, stmts)
+
+	desired.Columns = []qb.Column{
+		{Name: "k", Type: "int"}, {Name: "c", Type: "int"},
+		{Name: "a", Type: "set<text>"}, {Name: "s", Type: "int", Static: true},
+	}
+	desired.Options.Set("comment", qb.String("changed"))
+	desired.Options.Set("default_time_to_live", qb.Int(3600))
+	stmts, err = AlterTable(actual, desired)
+	require.NoError(t, err)
+	assert.Equal(t, []qb.CQL{
+		`ALTER TABLE "ks"."t" DROP "v"`,
+		`ALTER TABLE "ks"."t" ADD ("a" set<text>, "s" int STATIC)`,
+		`ALTER TABLE "ks"."t" WITH comment = 'changed' AND default_time_to_live = 3600`,
+	}, stmts)
+}
+
+func TestAlterTable_EquivalentTypes(t *testing.T) {
+	actual, desired := testTable(), testTable()
+	actual.Columns = append(actual.Columns, qb.Column{Name: "m", Type: "map<text, frozen<list<int>>>"})
+	desired.Columns = append(desired.Columns, qb.Column{Name: "m", Type: "MAP<varchar,FROZEN<list<int>>>"},
+		qb.Column{Name: "added", Type: "text"})
+	stmts, err := AlterTable(actual, desired)
+	require.NoError(t, err)
+	assert.Equal(t, []qb.CQL{`ALTER TABLE "ks"."t" ADD "added" text`}, stmts)
+}
+
+func TestAlterTable_RequiresReplacement(t *testing.T) {
+	tests := map[string]func(*qb.Table){
+		"renamed":          func(t *qb.Table) { t.Name = "other" },
+		"partition key":    func(t *qb.Table) { t.PartitionKey = []string{"k", "c"}; t.ClusteringKey = nil },
+		"clustering order": func(t *qb.Table) { t.ClusteringKey[0].Descending = false },
+		"column type":      func(t *qb.Table) { t.Columns[2].Type = "int" },
+		"static":           func(t *qb.Table) { t.Columns[2].Static = true },
+	}
+	for name, modify := range tests {
+		t.Run(name, func(t *testing.T) {
+			desired := testTable()
+			modify(desired)
+			_, err := AlterTable(testTable(), desired)
+			assert.ErrorIs(t, err, ErrRequiresReplacement)
+		})
+	}
+}
+
+func TestAlterKeyspace(t *testing.T) {
+	keyspace := func(dc1 string) *Keyspace {
+		k := &Keyspace{Name: "ks"}
+		k.Options.SetMap("replication", map[string]string{"class": "NetworkTopologyStrategy", "dc1": dc1})
+		k.Options.Set("durable_writes", qb.Bool(true))
+		return k
+	}
+
+	stmts, err := AlterKeyspace(keyspace("3"), keyspace("3"))
+	require.NoError(t, err)
+	assert.Empty(t, stmts)
+
+	stmts, err = AlterKeyspace(keyspace("3"), keyspace("5"))
+	require.NoError(t, err)
+	assert.Equal(t, []qb.CQL{
+		`ALTER KEYSPACE "ks" WITH replication = {'class': 'NetworkTopologyStrategy', 'dc1': '5'}`,
+	}, stmts)
+
+	desired := keyspace("3")
+	desired.Name = "other"
+	_, err = AlterKeyspace(keyspace("3"), desired)
+	assert.ErrorIs(t, err, ErrRequiresReplacement)
+}
+
+func TestAlterKeyspace_Tablets(t *testing.T) {
+	vnodes := &Keyspace{Name: "ks"}
+	vnodes.Options.Set("durable_writes", qb.Bool(true))
+
+	desired := &Keyspace{Name: "ks"}
+	desired.Options.Set("durable_writes", qb.Bool(false))
+	desired.Options.Set("tablets", qb.Tablets(false, 0))
+	stmts, err := AlterKeyspace(vnodes, desired)
+	require.NoError(t, err)
+	assert.Equal(t, []qb.CQL{`ALTER KEYSPACE "ks" WITH durable_writes = false`}, stmts)
+
+	desired.Options.Set("tablets", qb.Tablets(true, 8))
+	_, err = AlterKeyspace(vnodes, desired)
+	assert.ErrorIs(t, err, ErrRequiresReplacement)
+}
@@ -4,6 +4,8 @@ import (
 	"context"
 	"flag"
 	"log"
+	"os"
+	"strings"
 
 	"github.com/hashicorp/terraform-plugin-framework/providerserver"
 	"github.com/kiwicom/terraform-provider-scylla/internal/provider"
@@ -40,9 +42,31 @@ func main() {
 		Debug:   debug,
 	}
 
-	err := providerserver.Serve(context.Background(), provider.New(version), opts)
+	providerFunc := provider.New(version)
+	if !offersProtocol6() {
+		opts.ProtocolVersion = 5
+		providerFunc = provider.NewProtocol5(version)
+	}
+
+	err := providerserver.Serve(context.Background(), providerFunc, opts)
 
 	if err != nil {
 		log.Fatal(err.Error())
 	}
 }
+
+// offersProtocol6 reports whether Terraform supports plugin protocol version 6.
+// Terraform passes the protocol versions it supports in the PLUGIN_PROTOCOL_VERSIONS environment variable,
+// versions before 1.0 only support version 5. The variable is not set when running with -debug.
+func offersProtocol6() bool {
+	versions, ok := os.LookupEnv("PLUGIN_PROTOCOL_VERSIONS")
+	if !ok {
+		return true
+	}
+	for _, v := range strings.Split(versions, ",") {
+		if strings.TrimSpace(v) == "6" {
+			return true
+		}
+	}
+	return false
+}